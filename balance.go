@@ -0,0 +1,57 @@
+package fox
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// balanceHost is the host of Twilio's core API, which serves account balances.
+var balanceHost = "api.twilio.com"
+
+// Balance describes an account's current balance.
+type Balance struct {
+	// AccountSid is the account the balance belongs to.
+	AccountSid string `json:"account_sid"`
+	// Amount is the account's balance, in units of Currency.
+	Amount float64 `json:"balance,string"`
+	// Currency is the currency unit of the Amount. E.g., "USD".
+	Currency string `json:"currency"`
+}
+
+// GetBalance retrieves the current balance of the Client's account, for example so that an
+// automated campaign can stop sending when funds run low. It returns ErrInvalidSID if the account
+// SID is malformed, or otherwise the response received from Twilio, or an error of the type
+// ErrorResponse.
+func (c *Client) GetBalance() (*Balance, error) {
+	if !c.authenticated() {
+		return nil, ErrNotAuthenticated
+	}
+	accountSID := c.creds().accountSID
+	if err := validateSID(accountSID, accountSIDPrefix); err != nil {
+		return nil, err
+	}
+
+	u := url.URL{
+		Scheme: scheme,
+		Host:   balanceHost,
+		Path:   path.Join("/2010-04-01/Accounts", accountSID, "Balance.json"),
+	}
+
+	r, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.do(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var b Balance
+	if err := c.decode(body, &b); err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
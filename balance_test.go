@@ -0,0 +1,41 @@
+package fox
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetBalance(t *testing.T) {
+	assert := assert.New(t)
+
+	var gotHost, gotPath string
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost, gotPath = r.Host, r.URL.Path
+		w.Write([]byte(`{"currency": "USD", "balance": "12.35", "account_sid": "` + accountSID + `"}`))
+	}))
+	defer server.Close()
+
+	t.Run("OK", func(t *testing.T) {
+		got, err := c.GetBalance()
+		assert.NoError(err)
+		assert.Equal(balanceHost, gotHost)
+		assert.Equal("/2010-04-01/Accounts/"+accountSID+"/Balance.json", gotPath)
+		assert.Equal(&Balance{AccountSid: accountSID, Amount: 12.35, Currency: "USD"}, got)
+	})
+
+	t.Run("ErrInvalidSID", func(t *testing.T) {
+		bc := c.Clone()
+		bc.accountSID = "SID"
+
+		_, err := bc.GetBalance()
+		assert.Equal(ErrInvalidSID, err)
+	})
+
+	t.Run("ErrNotAuthenticated", func(t *testing.T) {
+		_, err := NewClient(accountSID, "").GetBalance()
+		assert.Equal(ErrNotAuthenticated, err)
+	})
+}
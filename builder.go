@@ -0,0 +1,59 @@
+package fox
+
+import "time"
+
+// SendOptsBuilder builds a SendOpts by chaining setter calls, for example:
+//
+//	opts, err := fox.NewSendOpts().Quality(fox.QualitySuperfine).StoreMedia(false).Build()
+//
+// Its zero value is not usable; construct one with NewSendOpts.
+type SendOptsBuilder struct {
+	so SendOpts
+}
+
+// NewSendOpts constructs a new SendOptsBuilder, starting from the default send options.
+func NewSendOpts() *SendOptsBuilder {
+	return &SendOptsBuilder{so: *DefaultSendOpts()}
+}
+
+// Quality sets the quality, one of QualityStandard, QualityFine or QualitySuperfine.
+func (b *SendOptsBuilder) Quality(quality qualityType) *SendOptsBuilder {
+	b.so.Quality = quality
+	return b
+}
+
+// SIPAuth sets the username and password to use for authentication when sending to a SIP address.
+func (b *SendOptsBuilder) SIPAuth(username, password string) *SendOptsBuilder {
+	b.so.SIPAuthUsername = username
+	b.so.SIPAuthPassword = password
+	return b
+}
+
+// StatusCallback sets the URL to receive a request when the status of the fax changes.
+func (b *SendOptsBuilder) StatusCallback(url string) *SendOptsBuilder {
+	b.so.StatusCallback = url
+	return b
+}
+
+// StoreMedia sets whether or not to store a copy of the sent media on Twilio's servers.
+func (b *SendOptsBuilder) StoreMedia(storeMedia bool) *SendOptsBuilder {
+	b.so.StoreMedia = storeMedia
+	return b
+}
+
+// TTL sets the duration from when a fax was initiated should Twilio attempt to send the fax.
+func (b *SendOptsBuilder) TTL(ttl time.Duration) *SendOptsBuilder {
+	b.so.TTL = ttl
+	return b
+}
+
+// Build returns a new SendOpts with the options set so far, or the first error reported by
+// SendOpts.Validate.
+func (b *SendOptsBuilder) Build() (*SendOpts, error) {
+	so := b.so
+	if err := so.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &so, nil
+}
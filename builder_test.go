@@ -0,0 +1,51 @@
+package fox
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendOptsBuilder(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		got, err := NewSendOpts().
+			Quality(QualitySuperfine).
+			SIPAuth("username", "password").
+			StatusCallback("https://example.com/callback").
+			StoreMedia(false).
+			TTL(10 * time.Minute).
+			Build()
+		assert.NoError(err)
+
+		want := &SendOpts{
+			Quality:         QualitySuperfine,
+			SIPAuthUsername: "username",
+			SIPAuthPassword: "password",
+			StatusCallback:  "https://example.com/callback",
+			StoreMedia:      false,
+			TTL:             10 * time.Minute,
+		}
+		assert.Equal(want, got)
+
+		gotData, wantData := url.Values{}, url.Values{}
+		got.urlEncode(gotData)
+		want.urlEncode(wantData)
+		assert.Equal(wantData.Encode(), gotData.Encode())
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		got, err := NewSendOpts().Build()
+		assert.NoError(err)
+		assert.Equal(DefaultSendOpts(), got)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		got, err := NewSendOpts().SIPAuth("username", "").Build()
+		assert.Nil(got)
+		assert.Equal(ErrIncompleteSIPAuth, err)
+	})
+}
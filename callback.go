@@ -0,0 +1,243 @@
+package fox
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// callbackSignatureParam is the query parameter in which SignCallbackURL places its signature.
+const callbackSignatureParam = "FoxSignature"
+
+// SignCallbackURL returns base with params added to its query string, along with an HMAC-SHA256
+// signature of the result keyed with the Client's auth token (or API key secret). It's intended for
+// status callback URLs pointing at the caller's own endpoint, which can then be checked with
+// VerifyCallbackURL when the callback arrives. This is a second layer of verification in addition
+// to, not a replacement for, Twilio's own X-Twilio-Signature header. It returns an empty string if
+// the Client has no auth token or API key secret to sign with, or if
+// base can't be parsed as a URL.
+func (c *Client) SignCallbackURL(base string, params url.Values) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return ""
+	}
+
+	q := u.Query()
+	for k, vs := range params {
+		for _, v := range vs {
+			q.Add(k, v)
+		}
+	}
+	q.Del(callbackSignatureParam)
+
+	u.RawQuery = q.Encode()
+	sig := c.callbackSignature(u.String())
+	if sig == "" {
+		return ""
+	}
+	q.Set(callbackSignatureParam, sig)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// VerifyCallbackURL reports whether rawURL carries a valid signature as added by SignCallbackURL,
+// meaning neither the URL nor its query parameters have been altered since it was signed. It
+// always returns false if the Client has no auth token or API key secret to verify with.
+func (c *Client) VerifyCallbackURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	q := u.Query()
+	sig := q.Get(callbackSignatureParam)
+	if sig == "" {
+		return false
+	}
+	q.Del(callbackSignatureParam)
+
+	u.RawQuery = q.Encode()
+	want := c.callbackSignature(u.String())
+	if want == "" {
+		return false
+	}
+
+	return hmac.Equal([]byte(sig), []byte(want))
+}
+
+// callbackSignature returns the base64-encoded HMAC-SHA256 of s, keyed with the Client's secret,
+// or an empty string if the Client has no secret, since a signature keyed with an empty secret
+// could be forged by anyone.
+func (c *Client) callbackSignature(s string) string {
+	_, secret := c.basicAuth()
+	if secret == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(s))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// twilioSignatureHeader is the header in which Twilio signs the requests it makes to callback URLs.
+const twilioSignatureHeader = "X-Twilio-Signature"
+
+// ValidateSignature reports whether r, a request made by Twilio to a callback URL, carries a valid
+// X-Twilio-Signature header: the base64-encoded HMAC-SHA1, keyed with the account's auth token, of
+// the request URL followed by each POST parameter's name and value, sorted by name. r's form is
+// parsed. The URL is reconstructed from r, so behind a proxy that rewrites the scheme, host or path,
+// the signature won't match. Twilio signs with the auth token, so a Client constructed with
+// NewClientWithAPIKey can't validate signatures.
+func (c *Client) ValidateSignature(r *http.Request) bool {
+	sig := r.Header.Get(twilioSignatureHeader)
+	if sig == "" || c.creds().authToken == "" {
+		return false
+	}
+	if err := r.ParseForm(); err != nil {
+		return false
+	}
+
+	return hmac.Equal([]byte(sig), []byte(c.twilioSignature(requestURL(r), r.PostForm)))
+}
+
+// twilioSignature returns the signature Twilio gives a request to rawURL with the POST parameters
+// params.
+func (c *Client) twilioSignature(rawURL string, params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	mac := hmac.New(sha1.New, []byte(c.creds().authToken))
+	mac.Write([]byte(rawURL))
+	for _, k := range keys {
+		for _, v := range params[k] {
+			mac.Write([]byte(k + v))
+		}
+	}
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// requestURL returns the absolute URL that r was made to, as seen by the server receiving it.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// ParseStatusCallback parses the form-encoded body of a status callback request made by Twilio. It
+// doesn't check the request's signature; see ValidateSignature.
+func ParseStatusCallback(r *http.Request) (*StatusCallbackResponse, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	f := r.PostForm
+	cb := StatusCallbackResponse{
+		FaxSid:           f.Get("FaxSid"),
+		AccountSid:       f.Get("AccountSid"),
+		From:             f.Get("From"),
+		To:               f.Get("To"),
+		RemoteStationID:  f.Get("RemoteStationId"),
+		FaxStatus:        f.Get("FaxStatus"),
+		APIVersion:       f.Get("ApiVersion"),
+		OriginalMediaURL: f.Get("OriginalMediaUrl"),
+		MediaURL:         f.Get("MediaUrl"),
+		ErrorMessage:     f.Get("ErrorMessage"),
+		Quality:          f.Get("Quality"),
+	}
+	if cb.FaxSid == "" {
+		return nil, ErrMissingSID
+	}
+
+	var err error
+	if cb.NumPages, err = formInt(f, "NumPages"); err != nil {
+		return nil, err
+	}
+	if cb.ErrorCode, err = formInt(f, "ErrorCode"); err != nil {
+		return nil, err
+	}
+
+	return &cb, nil
+}
+
+// formInt returns the integer value of the form field key, or 0 if it's absent or empty.
+func formInt(f url.Values, key string) (int, error) {
+	v := f.Get(key)
+	if v == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(v)
+}
+
+// NewCallbackHandler returns an http.Handler for Twilio status callbacks. It responds 403 FORBIDDEN
+// to a request whose signature c doesn't validate (see ValidateSignature), and 400 BAD REQUEST to
+// one that can't be parsed (see ParseStatusCallback). Otherwise, it calls onDelivered for a
+// delivered fax or onFailed for a fax that failed, was busy or went unanswered, and responds 200 OK.
+// Other statuses are acknowledged without a call. Either function may be nil.
+func NewCallbackHandler(c *Client, onDelivered, onFailed func(*StatusCallbackResponse)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !c.ValidateSignature(r) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		cb, err := ParseStatusCallback(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch st := cb.FaxStatusType(); {
+		case st == StatusDelivered && onDelivered != nil:
+			onDelivered(cb)
+		case st.IsFailure() && onFailed != nil:
+			onFailed(cb)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// SendAwaitCallback sends a fax like Send, with the Client's send options, then waits for its status
+// callback on ch rather than polling Twilio, returning the first callback whose FaxSid matches the
+// sent fax. ch is typically fed by a callback handler such as one from NewCallbackHandler, and the
+// send options' StatusCallback must point at it. Callbacks for other faxes are received and
+// discarded, so ch shouldn't be shared with other receivers. It returns ctx's error if ctx is done
+// first, or ErrCallbackChannelClosed if ch is closed.
+func (c *Client) SendAwaitCallback(ctx context.Context, to, from, mediaURL string, ch <-chan *StatusCallbackResponse) (*StatusCallbackResponse, error) {
+	so := c.sendOptsSnapshot()
+
+	sr, err := c.send(ctx, to, from, mediaURL, &so)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case cb, ok := <-ch:
+			if !ok {
+				return nil, ErrCallbackChannelClosed
+			}
+			if cb != nil && cb.FaxSid == sr.SID {
+				return cb, nil
+			}
+		}
+	}
+}
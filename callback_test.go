@@ -0,0 +1,201 @@
+package fox
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SignCallbackURL(t *testing.T) {
+	assert := assert.New(t)
+
+	params := url.Values{"order": {"1234"}, "customer": {"42"}}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		got := c.SignCallbackURL("https://example.com/callback?source=fox", params)
+		assert.Contains(got, "order=1234")
+		assert.Contains(got, "source=fox")
+		assert.Contains(got, callbackSignatureParam+"=")
+		assert.True(c.VerifyCallbackURL(got))
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		got := c.SignCallbackURL("https://example.com/callback", params)
+
+		assert.False(c.VerifyCallbackURL(strings.Replace(got, "order=1234", "order=1235", 1)))
+		assert.False(c.VerifyCallbackURL(strings.Replace(got, "example.com", "example.org", 1)))
+		assert.False(c.VerifyCallbackURL(got + "&extra=1"))
+	})
+
+	t.Run("DifferentSecret", func(t *testing.T) {
+		got := c.SignCallbackURL("https://example.com/callback", params)
+		assert.False(NewClient(accountSID, "OTHER_TOKEN").VerifyCallbackURL(got))
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		assert.False(c.VerifyCallbackURL("https://example.com/callback?order=1234"))
+	})
+
+	t.Run("EmptySecret", func(t *testing.T) {
+		// An HMAC keyed with an empty secret can be computed by anyone, so it must never verify.
+		mac := hmac.New(sha256.New, nil)
+		mac.Write([]byte("https://example.com/callback?order=1234"))
+		forged := "https://example.com/callback?order=1234&" + callbackSignatureParam + "=" +
+			base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+		pc := NewClient("", "")
+		pc.AuthProvider = func() (string, string, error) { return accountSID, authToken, nil }
+
+		assert.Equal("", pc.SignCallbackURL("https://example.com/callback", params))
+		assert.False(pc.VerifyCallbackURL(forged))
+	})
+}
+
+// newCallbackRequest returns a status callback request posting params to the callback URL, signed
+// as Twilio would with signer's auth token.
+func newCallbackRequest(signer *Client, params url.Values) *http.Request {
+	const callbackURL = "http://example.com/callback?order=1234"
+
+	r := httptest.NewRequest(http.MethodPost, callbackURL, strings.NewReader(params.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set(twilioSignatureHeader, signer.twilioSignature(callbackURL, params))
+
+	return r
+}
+
+func TestClient_ValidateSignature(t *testing.T) {
+	assert := assert.New(t)
+
+	params := url.Values{"FaxSid": {faxSID}, "FaxStatus": {"delivered"}}
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.True(c.ValidateSignature(newCallbackRequest(c, params)))
+	})
+
+	t.Run("KnownSignature", func(t *testing.T) {
+		// The example from Twilio's security documentation.
+		tc := NewClient(accountSID, "12345")
+		got := tc.twilioSignature("https://mycompany.com/myapp.php?foo=1&bar=2", url.Values{
+			"CallSid": {"CA1234567890ABCDE"},
+			"Caller":  {"+12349013030"},
+			"Digits":  {"1234"},
+			"From":    {"+12349013030"},
+			"To":      {"+18005551212"},
+		})
+		assert.Equal("0/KCTR6DLpKmkAf8muzZqo1nDgQ=", got)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.False(c.ValidateSignature(newCallbackRequest(NewClient(accountSID, "OTHER_TOKEN"), params)))
+
+		r := newCallbackRequest(c, params)
+		r.Header.Del(twilioSignatureHeader)
+		assert.False(c.ValidateSignature(r))
+	})
+}
+
+func TestParseStatusCallback(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		got, err := ParseStatusCallback(newCallbackRequest(c, url.Values{
+			"FaxSid":          {faxSID},
+			"FaxStatus":       {"delivered"},
+			"RemoteStationId": {"FOX"},
+			"NumPages":        {"3"},
+			"Quality":         {"fine"},
+		}))
+		assert.NoError(err)
+		assert.Equal(faxSID, got.FaxSid)
+		assert.Equal(StatusDelivered, got.FaxStatusType())
+		assert.Equal("FOX", got.RemoteStationID)
+		assert.Equal(3, got.NumPages)
+		assert.Equal(0, got.ErrorCode)
+		assert.Equal("fine", got.Quality)
+	})
+
+	t.Run("ErrMissingSID", func(t *testing.T) {
+		_, err := ParseStatusCallback(newCallbackRequest(c, url.Values{"FaxStatus": {"delivered"}}))
+		assert.Equal(ErrMissingSID, err)
+	})
+
+	t.Run("InvalidNumPages", func(t *testing.T) {
+		_, err := ParseStatusCallback(newCallbackRequest(c, url.Values{"FaxSid": {faxSID}, "NumPages": {"x"}}))
+		assert.Error(err)
+	})
+}
+
+func TestNewCallbackHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	var delivered, failed []string
+	h := NewCallbackHandler(c,
+		func(cb *StatusCallbackResponse) { delivered = append(delivered, cb.FaxSid) },
+		func(cb *StatusCallbackResponse) { failed = append(failed, cb.FaxStatus) },
+	)
+
+	serve := func(r *http.Request) int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	assert.Equal(http.StatusOK, serve(newCallbackRequest(c, url.Values{"FaxSid": {faxSID}, "FaxStatus": {"delivered"}})))
+	assert.Equal(http.StatusOK, serve(newCallbackRequest(c, url.Values{"FaxSid": {faxSID}, "FaxStatus": {"no-answer"}})))
+	assert.Equal(http.StatusOK, serve(newCallbackRequest(c, url.Values{"FaxSid": {faxSID}, "FaxStatus": {"sending"}})))
+	assert.Equal([]string{faxSID}, delivered)
+	assert.Equal([]string{"no-answer"}, failed)
+
+	forged := newCallbackRequest(NewClient(accountSID, "OTHER_TOKEN"), url.Values{"FaxSid": {faxSID}, "FaxStatus": {"delivered"}})
+	assert.Equal(http.StatusForbidden, serve(forged))
+	assert.Equal(http.StatusBadRequest, serve(newCallbackRequest(c, url.Values{"FaxStatus": {"delivered"}})))
+	assert.Len(delivered, 1)
+}
+
+func TestClient_SendAwaitCallback(t *testing.T) {
+	assert := assert.New(t)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(sendResponseJSON))
+	}))
+	defer server.Close()
+
+	t.Run("OK", func(t *testing.T) {
+		ch := make(chan *StatusCallbackResponse, 2)
+		ch <- &StatusCallbackResponse{FaxSid: "FX0000000000000000000000000000000A", FaxStatus: "failed"}
+		ch <- &StatusCallbackResponse{FaxSid: faxSID, FaxStatus: "delivered"}
+
+		got, err := c.SendAwaitCallback(context.Background(), to, from, faxMediaURL, ch)
+		assert.NoError(err)
+		if assert.NotNil(got) {
+			assert.Equal(faxSID, got.FaxSid)
+			assert.Equal(StatusDelivered, got.FaxStatusType())
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := c.SendAwaitCallback(ctx, to, from, faxMediaURL, make(chan *StatusCallbackResponse))
+		assert.Equal(context.DeadlineExceeded, err)
+	})
+
+	t.Run("ErrCallbackChannelClosed", func(t *testing.T) {
+		ch := make(chan *StatusCallbackResponse)
+		close(ch)
+
+		_, err := c.SendAwaitCallback(context.Background(), to, from, faxMediaURL, ch)
+		assert.Equal(ErrCallbackChannelClosed, err)
+	})
+}
@@ -1,12 +1,22 @@
 package fox
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,47 +24,400 @@ import (
 // complete before timing out.
 const DefaultTimeoutDuration = 10 * time.Second
 
+// DefaultMediaTimeoutDuration is the default length of time for a Client to wait for a media
+// download to complete before timing out. Media can be much larger than an API response, so it's
+// given longer.
+const DefaultMediaTimeoutDuration = 60 * time.Second
+
+// DefaultSendTimeoutDuration is the default length of time for a Client to wait for a send to
+// complete before timing out. Twilio may fetch the media before responding to a send, which can
+// take much longer than DefaultTimeoutDuration for a large document.
+const DefaultSendTimeoutDuration = 30 * time.Second
+
+// DefaultContentType is the Content-Type a Client sets on the form-encoded bodies of its requests
+// unless ContentType is set. Its non-standard "param=value" parameter is kept for compatibility.
+const DefaultContentType = "application/x-www-form-urlencoded; param=value"
+
+// maxMediaRedirects is the maximum number of redirects followed when downloading media.
+const maxMediaRedirects = 3
+
+// terminalCacheFactor is the multiple of CacheTTL for which faxes in a terminal status are cached.
+const terminalCacheFactor = 10
+
+// pollMin and pollMax are the bounds of the interval at which SendWithFallback and FaxTracker.Wait
+// poll a fax for a terminal status. They're variables so that tests can shorten them.
+var (
+	pollMin = 5 * time.Second
+	pollMax = time.Minute
+)
+
+// sidKey is the JSON key of a fax's SID, counted by fetchList to size the list it decodes into.
+var sidKey = []byte(`"sid"`)
+
+// listBufPool holds the buffers that fetchList reads list responses into.
+var listBufPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// requestDurationHeader is the header in which Twilio reports the time, in seconds, it spent
+// handling a request.
+const requestDurationHeader = "Twilio-Request-Duration"
+
+// rateLimitHeaderPrefix is the canonical prefix of the headers Twilio uses to report rate limits.
+const rateLimitHeaderPrefix = "Twilio-Ratelimit-"
+
 // Client describes an encapsulation of an HTTP client, send options and Twilio credentials.
+//
+// A Client is safe for concurrent use by multiple goroutines, provided its exported fields (and the
+// SendOpts it points to) are not modified while requests are in flight. The Client never mutates
+// SendOpts itself. To change the send options while sends may be in flight, use SetSendOpts.
 type Client struct {
 	HTTPClient      *http.Client
 	TimeoutDuration time.Duration
 	SendOpts        *SendOpts
-	accountSID      string
-	authToken       string
+	// MediaTimeout, if longer than the HTTP client's timeout, replaces it for media downloads,
+	// including reading the media. Like SendTimeout, it never shortens the HTTP client's timeout, nor
+	// imposes one on an HTTP client without a timeout. NewClient sets it to
+	// DefaultMediaTimeoutDuration.
+	MediaTimeout time.Duration
+	// SendTimeout, if longer than the HTTP client's timeout, replaces it for sends, since Twilio may
+	// fetch the media before responding to one. It never shortens the HTTP client's timeout, nor
+	// imposes one on an HTTP client without a timeout. NewClient sets it to
+	// DefaultSendTimeoutDuration.
+	SendTimeout time.Duration
+	// TraceHook, if set, is called after each request with the DNS lookup, connect, TLS handshake
+	// and time-to-first-byte durations captured for it. Tracing is disabled when TraceHook is nil.
+	TraceHook func(*TraceInfo)
+	// CacheTTL, if non-zero, is the length of time for which Get caches each fax it retrieves, so
+	// that repeated calls for the same SID within that time don't reach Twilio. Faxes in a terminal
+	// status won't change again, so are cached for terminalCacheFactor times as long. The cached
+	// fax is invalidated when it's canceled or deleted through the Client.
+	CacheTTL time.Duration
+	// TestMode indicates that the Client was constructed with Twilio's test credentials, under which
+	// no faxes are actually sent and responses are simulated. It doesn't change how requests are made;
+	// it's provided so that callers sharing a Client can branch on it, for example to skip waiting
+	// for a delivery that will never happen.
+	TestMode bool
+	// CheckMediaURL, if set, causes Send to check its media URL before sending, returning
+	// ErrInvalidMediaURL if it isn't an absolute HTTP(S) URL, or ErrPrivateMediaURL if its host is
+	// obviously private (localhost, or a loopback or private network address). Without it, such
+	// mistakes only surface once Twilio fails to fetch the media.
+	CheckMediaURL bool
+	// CheckMediaReachable, if set along with CheckMediaURL, additionally causes Send to issue a HEAD
+	// request for the media URL, returning an error matching ErrMediaUnreachable if it fails.
+	CheckMediaReachable bool
+	// StartSpan, if set, is called to start a Span around each request, such as one wrapping an
+	// OpenTelemetry span. The Span is given the request's method, fax SID and response status code as
+	// attributes, and any error, before being ended.
+	StartSpan SpanStarter
+	// StrictDecode, if set, causes responses carrying fields unknown to this package to be reported
+	// as errors rather than silently ignored. It's intended for use during development.
+	StrictDecode bool
+	// ErrorDecoder, if set, is given the body and status code of each error response (one with a
+	// status of 400 or above) before the built-in decoding. It's useful when Twilio is behind a
+	// gateway that reshapes error bodies. A non-nil error it returns is returned by the request's
+	// method; if it returns nil, the response is decoded as usual.
+	ErrorDecoder func(body []byte, statusCode int) error
+	// DedupeWindow, if non-zero, is the length of time for which Send remembers each fax it sends
+	// successfully, keyed by its to and from numbers and media URL. An identical Send within that
+	// time, or while the first is still in flight, returns a copy of the first's response rather
+	// than sending the fax again. It guards against accidental double sends, not intentional ones.
+	DedupeWindow time.Duration
+	// RequestMutator, if set, is called with each request after its credentials are set and just
+	// before it's sent, so that it can, for example, rewrite the URL for a proxy in front of Twilio or
+	// add a signature. If it returns an error, the request isn't sent and the error is returned.
+	RequestMutator func(*http.Request) error
+	// ContentType, if set, replaces DefaultContentType as the Content-Type of the form-encoded bodies
+	// of sends, such as with a plain "application/x-www-form-urlencoded" for a strict
+	// gateway in front of Twilio that rejects the default's "param=value" parameter.
+	ContentType string
+	// AuthProvider, if set, is called before each request to obtain the account SID and auth token
+	// to authenticate it with, overriding the Client's own credentials, such as when they're fetched
+	// from a vault that rotates them frequently. If it returns an error, the request isn't sent and
+	// the error is returned. The Client's own credentials are still used by GetBalance, for the
+	// account SID, and to sign and validate callbacks (see SignCallbackURL, VerifyCallbackURL and
+	// ValidateSignature), which therefore fail for a Client with no static secret.
+	AuthProvider func() (sid, token string, err error)
+	// Clock, if set, replaces the real clock for polling intervals and for the expiry of cached faxes
+	// and deduplicated sends, so that tests can control time. Request timeouts always use real time.
+	Clock Clock
+
+	credMu       sync.RWMutex // guards accountSID, authToken, apiKeySID and apiKeySecret
+	accountSID   string
+	authToken    string
+	apiKeySID    string
+	apiKeySecret string
+	apiVersion   string
+
+	mu          sync.Mutex                         // guards SendOpts, lastHeader and the fields below
+	lastHeader  http.Header                        // headers of the most recent response
+	cache       map[string]cacheEntry              // faxes retrieved by Get, keyed by SID
+	cachePruned time.Time                          // when expired faxes were last pruned from cache
+	sends       map[[sha256.Size]byte]*dedupeEntry // sends tracked for DedupeWindow; see dedupeKey
+	sendsPruned time.Time                          // when expired sends were last pruned from sends
+}
+
+// cacheEntry describes a fax cached by Get.
+type cacheEntry struct {
+	sr      SendResponse
+	expires time.Time
+}
+
+// Option configures a Client on construction. A *SendOpts is itself an Option, which sets the
+// Client's send options.
+type Option interface {
+	apply(c *Client)
+}
+
+// optionFunc adapts a function to the Option interface.
+type optionFunc func(c *Client)
+
+func (f optionFunc) apply(c *Client) {
+	f(c)
+}
+
+// apply satisfies the Option interface, setting the Client's send options to so.
+func (so *SendOpts) apply(c *Client) {
+	c.SendOpts = so
+}
+
+// WithHTTPClient is an Option that makes the Client send requests with hc, as-is, rather than with
+// a new HTTP client. The caller is then responsible for hc's timeout; DefaultTimeoutDuration isn't
+// applied, though the Client's MediaTimeout and SendTimeout still extend a shorter one where they
+// apply.
+func WithHTTPClient(hc *http.Client) Option {
+	return optionFunc(func(c *Client) {
+		c.HTTPClient = hc
+	})
+}
+
+// WithAPIVersion is an Option that makes the Client target the given version of the fax API, such
+// as "v1", rather than the default of "v1". An empty version is invalid and is ignored.
+func WithAPIVersion(version string) Option {
+	return optionFunc(func(c *Client) {
+		if version != "" {
+			c.apiVersion = version
+		}
+	})
+}
+
+// WithProxy is an Option that makes the Client send requests through the HTTP proxy at proxyURL,
+// rather than the one given by the environment. It applies to the Client's HTTP transport as it
+// stands when the option is applied, so should follow any WithHTTPClient option. If proxyURL can't
+// be parsed, every request fails with the parse error. An HTTP client supplied with WithHTTPClient
+// isn't modified; the Client uses a copy of it instead. A transport that's neither nil nor an
+// *http.Transport, whose proxying is up to it, is left as it is.
+func WithProxy(proxyURL string) Option {
+	return optionFunc(func(c *Client) {
+		t, ok := c.cloneTransport()
+		if !ok {
+			return
+		}
+
+		u, err := url.Parse(proxyURL)
+		t.Proxy = func(*http.Request) (*url.URL, error) {
+			return u, err
+		}
+
+		// Replace the transport on a copy, since the HTTP client may be the caller's own.
+		hc := *c.HTTPClient
+		hc.Transport = t
+		c.HTTPClient = &hc
+	})
 }
 
-// NewClient constructs a new Client given a Twilio account SID, auth token and an optional
-// pointer to a SendOpts object. If no argument is supplied for sendOpts, the default send options
-// are used.
+// NewClient constructs a new Client given a Twilio account SID, auth token and optional Options,
+// such as a pointer to a SendOpts object. If no SendOpts is supplied, a fresh copy of the default
+// send options is used.
 //
-// By default, the HTTP client sets its request timeout duration to DefaultTimeDuration. To
-// override, assign a new time.Duration value to HTTPClient.Timeout.
-func NewClient(accountSID, authToken string, sendOpts ...*SendOpts) *Client {
+// By default, the HTTP client sets its request timeout duration to DefaultTimeoutDuration, which
+// sends extend to SendTimeout and media downloads to MediaTimeout. To override, assign a new time.Duration value to HTTPClient.Timeout,
+// or supply an HTTP client of your own with WithHTTPClient. The default HTTP client's transport sends requests through the
+// proxy given by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables (see
+// http.ProxyFromEnvironment); use WithProxy to specify one explicitly.
+func NewClient(accountSID, authToken string, opts ...Option) *Client {
 	c := Client{
 		HTTPClient: &http.Client{
-			Timeout: DefaultTimeoutDuration,
+			Transport: newTransport(),
+			Timeout:   DefaultTimeoutDuration,
 		},
-		accountSID: accountSID,
-		authToken:  authToken,
+		MediaTimeout: DefaultMediaTimeoutDuration,
+		SendTimeout:  DefaultSendTimeoutDuration,
+		SendOpts:     DefaultSendOpts(),
+		accountSID:   accountSID,
+		apiVersion:   version,
+		authToken:    authToken,
 	}
 
-	if len(sendOpts) > 0 {
-		c.SendOpts = sendOpts[0]
-	} else {
-		c.SendOpts = DefaultSendOpts
+	for _, opt := range opts {
+		opt.apply(&c)
 	}
 
 	return &c
 }
 
-// Cancel updates a single fax instance by its SID with the "canceled" status. An error of the type
-// ErrorResponse is returned on any failure.
-func (c *Client) Cancel(sid string) error {
-	if c.accountSID == "" || c.authToken == "" {
-		return ErrNotAuthenticated
+// NewClientStrict constructs a new Client like NewClient, but first checks the format of the
+// account SID and auth token with ValidateCredentials, so a mistyped credential is reported
+// immediately rather than by the first API call.
+func NewClientStrict(accountSID, authToken string, opts ...Option) (*Client, error) {
+	c := NewClient(accountSID, authToken, opts...)
+	if err := c.ValidateCredentials(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// NewClientWithAPIKey constructs a new Client given a Twilio account SID, the SID and secret of an
+// API key belonging to that account (or one of its subaccounts) and optional Options, as for
+// NewClient. Requests are authenticated with the API key rather than the account's auth token.
+func NewClientWithAPIKey(accountSID, keySID, keySecret string, opts ...Option) *Client {
+	c := NewClient(accountSID, "", opts...)
+	c.apiKeySID = keySID
+	c.apiKeySecret = keySecret
+
+	return c
+}
+
+// Clone returns a new Client with the same credentials and settings as c, sharing its HTTP client.
+// The clone's SendOpts is a copy of c's, so either can be modified without affecting the other.
+// The clone starts with an empty Get cache and no last response headers.
+func (c *Client) Clone() *Client {
+	cr := c.creds()
+
+	clone := Client{
+		HTTPClient:          c.HTTPClient,
+		TimeoutDuration:     c.TimeoutDuration,
+		MediaTimeout:        c.MediaTimeout,
+		SendTimeout:         c.SendTimeout,
+		TraceHook:           c.TraceHook,
+		CacheTTL:            c.CacheTTL,
+		TestMode:            c.TestMode,
+		CheckMediaURL:       c.CheckMediaURL,
+		CheckMediaReachable: c.CheckMediaReachable,
+		StartSpan:           c.StartSpan,
+		StrictDecode:        c.StrictDecode,
+		ErrorDecoder:        c.ErrorDecoder,
+		DedupeWindow:        c.DedupeWindow,
+		RequestMutator:      c.RequestMutator,
+		AuthProvider:        c.AuthProvider,
+		ContentType:         c.ContentType,
+		Clock:               c.Clock,
+		SendOpts:            c.cloneSendOpts(),
+		accountSID:          cr.accountSID,
+		authToken:           cr.authToken,
+		apiKeySID:           cr.apiKeySID,
+		apiKeySecret:        cr.apiKeySecret,
+		apiVersion:          c.apiVersion,
+	}
+
+	return &clone
+}
+
+// SetSendOpts replaces the Client's send options with a copy of so. Unlike assigning SendOpts, it's
+// safe to call while sends are in flight: each send uses the options in effect when it began.
+func (c *Client) SetSendOpts(so *SendOpts) {
+	cp := *so
+	if cp.Extra != nil {
+		cp.Extra = cloneValues(cp.Extra)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.SendOpts = &cp
+}
+
+// sendOptsSnapshot returns a copy of the Client's send options, as set by SetSendOpts.
+func (c *Client) sendOptsSnapshot() SendOpts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return *c.SendOpts
+}
+
+// cloneSendOpts returns a deep copy of the Client's send options, as set by SetSendOpts, or nil if
+// there are none.
+func (c *Client) cloneSendOpts() *SendOpts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.SendOpts == nil {
+		return nil
+	}
+
+	so := *c.SendOpts
+	if so.Extra != nil {
+		so.Extra = cloneValues(so.Extra)
+	}
+
+	return &so
+}
+
+// cloneValues returns a deep copy of v.
+func cloneValues(v url.Values) url.Values {
+	clone := make(url.Values, len(v))
+	for k, vs := range v {
+		clone[k] = append([]string(nil), vs...)
+	}
+
+	return clone
+}
+
+// TransportConfig describes the connection pooling parameters of a Client's HTTP transport. Zero
+// fields leave the corresponding net/http defaults in place.
+type TransportConfig struct {
+	// MaxIdleConns is the maximum number of idle connections kept across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept per host. High-volume
+	// senders may want to raise this, since net/http keeps only 2 by default.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is the maximum length of time an idle connection is kept before closing.
+	IdleConnTimeout time.Duration
+}
+
+// SetTransportConfig replaces the Client's HTTP transport with a copy, with the pooling parameters
+// in tc applied. The HTTP client is replaced with a copy too, preserving its timeout, so that clones
+// sharing the original are unaffected. If the transport is neither nil nor an *http.Transport, such
+// as one wrapping another for instrumentation, it's left as it is and ErrUnsupportedTransport is
+// returned.
+func (c *Client) SetTransportConfig(tc TransportConfig) error {
+	t, ok := c.cloneTransport()
+	if !ok {
+		return ErrUnsupportedTransport
+	}
+
+	if tc.MaxIdleConns > 0 {
+		t.MaxIdleConns = tc.MaxIdleConns
+	}
+	if tc.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = tc.MaxIdleConnsPerHost
+	}
+	if tc.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = tc.IdleConnTimeout
+	}
+
+	// Replace the transport on a copy, since the HTTP client may be shared with clones of the Client
+	// or be the caller's own.
+	hc := *c.HTTPClient
+	hc.Transport = t
+	c.HTTPClient = &hc
+
+	return nil
+}
+
+// Cancel updates a single fax instance by its SID with the "canceled" status. It returns the fax's
+// resulting state as received from Twilio, or an error of the type ErrorResponse. If Twilio responds
+// with no body, as with 204 NO CONTENT, the returned state carries only the SID and status.
+func (c *Client) Cancel(sid string) (*SendResponse, error) {
+	return c.cancel(context.Background(), sid)
+}
+
+// cancel implements Cancel, binding the request to ctx.
+func (c *Client) cancel(ctx context.Context, sid string) (*SendResponse, error) {
+	if !c.authenticated() {
+		return nil, ErrNotAuthenticated
 	}
 	if sid == "" {
-		return ErrMissingSID
+		return nil, ErrMissingSID
 	}
 
 	u := c.buildURL(sid)
@@ -62,19 +425,70 @@ func (c *Client) Cancel(sid string) error {
 	data := url.Values{}
 	data.Add("Status", StatusCanceled.String())
 
-	r, err := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(data.Encode()))
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(data.Encode()))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.do(r)
+	body, err := c.do(r)
+	c.uncache(sid)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return &SendResponse{SID: sid, Status: StatusCanceled.String()}, nil
+	}
+
+	var sr SendResponse
+	if err := c.decode(body, &sr); err != nil {
+		return nil, err
+	}
+
+	return &sr, nil
+}
+
+// CancelTo cancels every fax to the given number that hasn't yet reached a terminal status, such as
+// to stop a misdirected batch, returning the SIDs of the faxes canceled. It stops at the first
+// failure, returning the SIDs canceled until then along with the error.
+func (c *Client) CancelTo(ctx context.Context, to string) ([]string, error) {
+	if to == "" {
+		return nil, ErrMissingToNumber
+	}
+
+	faxes, err := c.listAll(ctx, &ListOpts{To: to})
+	if err != nil {
+		return nil, err
+	}
+
+	var canceled []string
+	for _, fax := range faxes {
+		if fax.To != to {
+			continue
+		}
+		if st, ok := parseStatus(fax.Status); ok && st.IsTerminal() {
+			continue
+		}
+
+		if _, err := c.cancel(ctx, fax.SID); err != nil {
+			return canceled, err
+		}
+		canceled = append(canceled, fax.SID)
+	}
+
+	return canceled, nil
+}
+
+// CancelSID cancels a single fax instance by its SID like Cancel, discarding the fax's resulting
+// state. An error of the type ErrorResponse is returned on any failure.
+func (c *Client) CancelSID(sid string) error {
+	_, err := c.Cancel(sid)
 	return err
 }
 
 // Delete removes a single fax instance by its SID any associated fax media instance. An error of
 // the type ErrorResponse is returned on any failure.
 func (c *Client) Delete(sid string) error {
-	if c.accountSID == "" || c.authToken == "" {
+	if !c.authenticated() {
 		return ErrNotAuthenticated
 	}
 	if sid == "" {
@@ -89,13 +503,45 @@ func (c *Client) Delete(sid string) error {
 	}
 
 	_, err = c.do(r)
+	c.uncache(sid)
 	return err
 }
 
 // Get retrieves the data for a single fax instance by its SID, or an error of the type
 // ErrorResponse.
 func (c *Client) Get(sid string) (*SendResponse, error) {
-	if c.accountSID == "" || c.authToken == "" {
+	return c.get(context.Background(), sid)
+}
+
+// get implements Get, binding the request to ctx.
+func (c *Client) get(ctx context.Context, sid string) (*SendResponse, error) {
+	if !c.authenticated() {
+		return nil, ErrNotAuthenticated
+	}
+	if sid == "" {
+		return nil, ErrMissingSID
+	}
+	if sr, ok := c.cached(sid); ok {
+		return sr, nil
+	}
+
+	u := c.buildURL(sid)
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, _, err := c.fetchFax(r, sid)
+	return sr, err
+}
+
+// GetIfModifiedSince retrieves the data for a single fax instance by its SID like Get, but asks
+// Twilio to respond only if the fax was updated after since, typically the DateUpdated of a
+// previously retrieved response. If it wasn't, ErrNotModified is returned and nothing is
+// downloaded. The cache is bypassed.
+func (c *Client) GetIfModifiedSince(sid string, since time.Time) (*SendResponse, error) {
+	if !c.authenticated() {
 		return nil, ErrNotAuthenticated
 	}
 	if sid == "" {
@@ -108,144 +554,1124 @@ func (c *Client) Get(sid string) (*SendResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	r.Header.Set("If-Modified-Since", since.UTC().Format(http.TimeFormat))
+
+	sr, _, err := c.fetchFax(r, sid)
+	return sr, err
+}
+
+// GetRaw retrieves the data for a single fax instance by its SID like Get, returning the response
+// body exactly as received from Twilio alongside the parsed fax, such as for an audit log. The cache
+// is bypassed, since it doesn't retain response bodies.
+func (c *Client) GetRaw(sid string) (*SendResponse, []byte, error) {
+	if !c.authenticated() {
+		return nil, nil, ErrNotAuthenticated
+	}
+	if sid == "" {
+		return nil, nil, ErrMissingSID
+	}
+
+	u := c.buildURL(sid)
+
+	r, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.fetchFax(r, sid)
+}
 
+// fetchFax performs r, a request for the fax with the given SID, and caches and returns the fax
+// along with the response body.
+func (c *Client) fetchFax(r *http.Request, sid string) (*SendResponse, []byte, error) {
 	body, err := c.do(r)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var sr SendResponse
-	if err := json.Unmarshal(body, &sr); err != nil {
-		return nil, err
+	if err := c.decode(body, &sr); err != nil {
+		return nil, nil, err
 	}
 
-	return &sr, nil
+	c.cacheFax(sid, &sr)
+	return &sr, body, nil
+}
+
+// GetMany retrieves the data for multiple fax instances by their SIDs, making at most concurrency
+// requests at once. Duplicate SIDs are fetched only once. It returns the faxes retrieved and the
+// errors encountered, each keyed by SID. If ctx is canceled, no further requests are made, and the
+// SIDs not yet fetched are reported with the context's error.
+func (c *Client) GetMany(ctx context.Context, sids []string, concurrency int) (map[string]*SendResponse, map[string]error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := map[string]*SendResponse{}
+	errs := map[string]error{}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+
+	seen := map[string]bool{}
+	for _, sid := range sids {
+		if seen[sid] {
+			continue
+		}
+		seen[sid] = true
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			errs[sid] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(sid string) {
+			defer func() { <-sem; wg.Done() }()
+
+			sr, err := c.get(ctx, sid)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs[sid] = err
+				return
+			}
+			results[sid] = sr
+		}(sid)
+	}
+
+	wg.Wait()
+	return results, errs
+}
+
+// WaitForTerminalBackoff polls the fax with the given SID until its status is terminal, returning
+// the final response. The interval between polls starts at min and doubles after each poll, up to
+// max, so fast completions are caught quickly while long transmissions cost few requests. Polling
+// stops with ctx's error if ctx is done first.
+func (c *Client) WaitForTerminalBackoff(ctx context.Context, sid string, min, max time.Duration) (*SendResponse, error) {
+	if min <= 0 || max < min {
+		return nil, ErrInvalidBackoff
+	}
+
+	interval := min
+
+	for {
+		c.uncache(sid)
+
+		sr, err := c.get(ctx, sid)
+		if err != nil {
+			return nil, err
+		}
+		if st, ok := parseStatus(sr.Status); ok && st.IsTerminal() {
+			return sr, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.clock().After(interval):
+		}
+
+		if interval *= 2; interval > max {
+			interval = max
+		}
+	}
 }
 
 // List retrieves the faxes in the account. An optional pointer to a ListOpts object can be supplied
 // to set filtering options. List returns the response received from Twilio, or an error of the type
 // ErrorResponse.
 func (c *Client) List(opts ...*ListOpts) (*ListResponse, error) {
-	if c.accountSID == "" || c.authToken == "" {
-		return nil, ErrNotAuthenticated
+	var lo *ListOpts
+	if len(opts) > 0 {
+		lo = opts[0]
 	}
 
-	u := c.buildURL("")
+	return c.list(context.Background(), lo)
+}
 
-	data := url.Values{}
+// ListContext is like List, but binds the request to ctx, so a deadline or cancelation set by the
+// caller applies to this call alone, independent of the HTTP client's timeout. If ctx is done
+// before the response is received, ctx's error is returned.
+func (c *Client) ListContext(ctx context.Context, opts ...*ListOpts) (*ListResponse, error) {
+	var lo *ListOpts
 	if len(opts) > 0 {
-		opts[0].urlEncode(data)
+		lo = opts[0]
 	}
 
-	r, err := http.NewRequest(http.MethodGet, u.String(), strings.NewReader(data.Encode()))
-	if err != nil {
-		return nil, err
-	}
+	return c.list(ctx, lo)
+}
 
-	r.Header.Set("Content-Type", "application/x-www-form-urlencoded; param=value")
+// ListAll retrieves the faxes in the account across all pages of results, following each page's
+// next page URL until there are none left. opts may be nil. If opts.Limit is non-zero, no further
+// pages are fetched once that many faxes have been collected, and any excess from the final page
+// is trimmed. ListAll returns the faxes collected, or an error of the type ErrorResponse.
+func (c *Client) ListAll(opts *ListOpts) ([]SendResponse, error) {
+	return c.listAll(context.Background(), opts)
+}
 
-	body, err := c.do(r)
+// listAll implements ListAll, binding each request to ctx.
+func (c *Client) listAll(ctx context.Context, opts *ListOpts) ([]SendResponse, error) {
+	lr, err := c.list(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	var lr ListResponse
-	if err := json.Unmarshal(body, &lr); err != nil {
-		return nil, err
+	limit := 0
+	if opts != nil {
+		limit = opts.Limit
 	}
 
-	return &lr, nil
-}
+	var faxes []SendResponse
+	for {
+		faxes = append(faxes, lr.Faxes...)
 
-// Send initiates a fax to the specified number. The arguments for the to and from numbers are
-// expected to be in the E.164 format, and the media URL argument is expected to be a
-// fully-qualified, publicly-accessible URL. It returns the response received from Twilio, or
-// an error of the type ErrorResponse.
-func (c *Client) Send(to, from, mediaURL string, sendOpts ...*SendOpts) (*SendResponse, error) {
-	if c.accountSID == "" || c.authToken == "" {
-		return nil, ErrNotAuthenticated
-	}
-	if to == "" {
-		return nil, ErrMissingToNumber
-	}
-	if from == "" {
-		return nil, ErrMissingFromNumber
-	}
-	if mediaURL == "" {
-		return nil, ErrMissingMediaURL
-	}
+		if limit > 0 && len(faxes) >= limit {
+			return faxes[:limit], nil
+		}
+		if lr.Meta.NextPageURL == "" {
+			return faxes, nil
+		}
 
-	var opts *SendOpts
-	if len(sendOpts) > 0 {
-		opts = sendOpts[0]
-	} else {
-		opts = c.SendOpts
+		lr, err = c.listPage(ctx, lr.Meta.NextPageURL)
+		if err != nil {
+			return nil, err
+		}
 	}
+}
 
-	u := c.buildURL("")
+// NextPage retrieves the page of faxes following lr, as given by its Meta.NextPageURL. It returns
+// ErrNoNextPage if lr is the last page, or otherwise the response received from Twilio, or an error
+// of the type ErrorResponse.
+func (c *Client) NextPage(lr *ListResponse) (*ListResponse, error) {
+	if !lr.HasNext() {
+		return nil, ErrNoNextPage
+	}
+	if !c.authenticated() {
+		return nil, ErrNotAuthenticated
+	}
 
-	data := url.Values{}
-	data.Add("To", to)
-	data.Add("From", from)
-	data.Add("MediaUrl", mediaURL)
-	opts.urlEncode(data)
+	return c.listPage(context.Background(), lr.Meta.NextPageURL)
+}
 
-	r, err := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(data.Encode()))
+// listPage retrieves the page of faxes at pageURL, as given by a ListResponse's Meta.
+func (c *Client) listPage(ctx context.Context, pageURL string) (*ListResponse, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	r.Header.Set("Content-Type", "application/x-www-form-urlencoded; param=value")
+	return c.fetchList(r)
+}
 
-	body, err := c.do(r)
+// fetchList performs r, a request for a page of faxes, and returns the page. Faxes is never nil.
+func (c *Client) fetchList(r *http.Request) (*ListResponse, error) {
+	res, err := c.doStream(c.HTTPClient, r)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
 
-	var sr SendResponse
-	if err := json.Unmarshal(body, &sr); err != nil {
+	// List responses can be large, so they're read into a reused buffer. Decoding copies everything
+	// it keeps, so the buffer can be returned to the pool afterwards.
+	buf := listBufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer listBufPool.Put(buf)
+
+	if _, err := buf.ReadFrom(res.Body); err != nil {
+		return nil, contextErr(r, err)
+	}
+	body := buf.Bytes()
+
+	// Decoding into a slice with enough capacity for every fax avoids growing it repeatedly. Each
+	// fax has exactly one "sid" key; the keys of other SIDs, such as "account_sid", don't match.
+	lr := ListResponse{Faxes: make([]SendResponse, 0, bytes.Count(body, sidKey))}
+	if err := c.decode(body, &lr); err != nil {
 		return nil, err
 	}
+	if lr.Faxes == nil {
+		lr.Faxes = []SendResponse{}
+	}
 
-	return &sr, nil
+	return &lr, nil
 }
 
-func (c *Client) buildURL(param string) *url.URL {
-	u := url.URL{}
-	u.Scheme = scheme
-	u.Host = host
-	u.Path = path.Join(version, endpoint, param)
-	return &u
+// ListSince retrieves the faxes in the account created within the duration d before now, such as
+// the last 24 hours. An optional pointer to a ListOpts object can be supplied to set further
+// filtering options; its DateCreatedAfter is overridden, and the ListOpts itself isn't modified.
+// ListSince returns the response received from Twilio, or an error of the type ErrorResponse.
+func (c *Client) ListSince(d time.Duration, opts ...*ListOpts) (*ListResponse, error) {
+	var lo ListOpts
+	if len(opts) > 0 && opts[0] != nil {
+		lo = *opts[0]
+	}
+	lo.DateCreatedAfter = c.clock().Now().Add(-d)
+
+	return c.list(context.Background(), &lo)
 }
 
-// do performs the actual request, setting authentication credentials and returning either a success
-// response body as a byte slice or an error of type ErrorResponse.
-func (c *Client) do(r *http.Request) ([]byte, error) {
-	r.SetBasicAuth(c.accountSID, c.authToken)
+// list implements List, binding the request to ctx. opts may be nil.
+func (c *Client) list(ctx context.Context, opts *ListOpts) (*ListResponse, error) {
+	if !c.authenticated() {
+		return nil, ErrNotAuthenticated
+	}
 
-	res, err := c.HTTPClient.Do(r)
+	r, err := c.newListRequest(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	body, err := ioutil.ReadAll(res.Body)
+	return c.fetchList(r)
+}
+
+// ListStream retrieves the faxes in the account like List, but rather than holding the whole
+// response in memory, decodes the faxes one at a time as they arrive, calling fn with each. opts may
+// be nil. If fn returns an error, decoding stops and ListStream returns that error. Otherwise, it
+// returns nil, or an error of the type ErrorResponse.
+func (c *Client) ListStream(opts *ListOpts, fn func(SendResponse) error) error {
+	if !c.authenticated() {
+		return ErrNotAuthenticated
+	}
+
+	r, err := c.newListRequest(context.Background(), opts)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Twilio returns 201 CREATED for fax resources created successfully via a POST request, 200 OK
-	// when retrieving resources via a GET request and 204 NO CONTENT when updating resources via a
+	res, err := c.doStream(c.HTTPClient, r)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return decodeFaxes(res.Body, fn)
+}
+
+// newListRequest constructs the request for listing faxes, bound to ctx. opts may be nil.
+func (c *Client) newListRequest(ctx context.Context, opts *ListOpts) (*http.Request, error) {
+	u := c.buildURL("")
+
+	data := url.Values{}
+	if opts != nil {
+		opts.urlEncode(data)
+	}
+
+	u.RawQuery = data.Encode()
+
+	return http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+}
+
+// Send initiates a fax to the specified number. The arguments for the to and from numbers are
+// expected to be in the E.164 format, and the media URL argument is expected to be a
+// fully-qualified, publicly-accessible URL, which Twilio fetches the media from; it doesn't accept
+// inline media such as a data: URI, so even small documents must be hosted. The send options are
+// checked with SendOpts.Validate before anything is sent. It returns the response received from
+// Twilio, or an error of the type ErrorResponse.
+//
+// Twilio responds to a send with the newly created fax, which is typically queued and hasn't been
+// processed or transmitted yet, so its NumPages, Price and Duration are nil. Use Get to retrieve
+// them once the fax has been delivered.
+//
+// Twilio may fetch the media before responding, so the send is subject to the Client's SendTimeout
+// where it's longer than its HTTP client's timeout.
+//
+// If DedupeWindow is set, a Send identical to a recent one returns that one's response instead.
+func (c *Client) Send(to, from, mediaURL string, sendOpts ...*SendOpts) (*SendResponse, error) {
+	// Send with a copy of the options, so that they're consistent throughout the send.
+	var so SendOpts
+	if len(sendOpts) > 0 && sendOpts[0] != nil {
+		so = *sendOpts[0]
+	} else {
+		so = c.sendOptsSnapshot()
+	}
+	opts := &so
+
+	if c.DedupeWindow > 0 {
+		return c.sendOnce(context.Background(), to, from, mediaURL, opts)
+	}
+
+	return c.send(context.Background(), to, from, mediaURL, opts)
+}
+
+// SendSummary returns a human-readable summary of what Send would transmit given the same arguments,
+// without sending anything: the to and from numbers and media URL, followed by the resolved send
+// options as given by SendOpts.Summary.
+func (c *Client) SendSummary(to, from, mediaURL string, sendOpts ...*SendOpts) string {
+	var so SendOpts
+	if len(sendOpts) > 0 && sendOpts[0] != nil {
+		so = *sendOpts[0]
+	} else {
+		so = c.sendOptsSnapshot()
+	}
+
+	return fmt.Sprintf("To: %s\nFrom: %s\nMediaUrl: %s\n", to, from, mediaURL) + so.Summary()
+}
+
+// send implements Send, binding the request to ctx.
+func (c *Client) send(ctx context.Context, to, from, mediaURL string, opts *SendOpts) (*SendResponse, error) {
+	if !c.authenticated() {
+		return nil, ErrNotAuthenticated
+	}
+	if to == "" {
+		return nil, ErrMissingToNumber
+	}
+	if from == "" {
+		return nil, ErrMissingFromNumber
+	}
+	if mediaURL == "" {
+		return nil, ErrMissingMediaURL
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if c.CheckMediaURL {
+		if err := c.preflightMediaURL(ctx, mediaURL); err != nil {
+			return nil, err
+		}
+	}
+
+	u := c.buildURL("")
+
+	data := url.Values{}
+	data.Add("To", to)
+	data.Add("From", from)
+	data.Add("MediaUrl", mediaURL)
+	opts.urlEncode(data)
+
+	// The body is a *strings.Reader, so http.NewRequestWithContext sets GetBody to rewind it, and a
+	// retried send is sent in full again.
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Header.Set("Content-Type", c.contentType())
+
+	body, err := c.doWith(c.sendHTTPClient(), r)
+	if err != nil {
+		return nil, err
+	}
+
+	var sr SendResponse
+	if err := c.decode(body, &sr); err != nil {
+		return nil, err
+	}
+
+	return &sr, nil
+}
+
+// ResendFailed resends every fax matching opts that ended in the failed, no-answer or busy status,
+// using its original to and from numbers, media URL and quality, and the Client's other send
+// options. Every page of faxes matching opts is examined, up to any Limit it sets; opts may be nil to
+// consider all faxes. Its dates, numbers and status are checked again against each listed fax, so
+// that a fax outside them is never resent. It returns the responses for the faxes resent.
+//
+// Faxes that couldn't be resent, including those whose media wasn't stored by Twilio, are reported
+// by an error of the type ResendError, which is returned alongside the faxes that were resent.
+func (c *Client) ResendFailed(ctx context.Context, opts *ListOpts) ([]*SendResponse, error) {
+	faxes, err := c.listAll(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var resent []*SendResponse
+	failed := ResendError{}
+
+	for _, fax := range faxes {
+		if !opts.matches(&fax) || !isFailedStatus(fax.Status) {
+			continue
+		}
+		if fax.MediaURL == "" {
+			failed[fax.SID] = ErrMissingMediaURL
+			continue
+		}
+
+		so := c.sendOptsSnapshot()
+		if q, err := ParseQuality(fax.Quality); err == nil {
+			so.Quality = q
+		}
+
+		sr, err := c.send(ctx, fax.To, fax.From, fax.MediaURL, &so)
+		if err != nil {
+			if ctx.Err() != nil {
+				return resent, ctx.Err()
+			}
+
+			failed[fax.SID] = err
+			continue
+		}
+
+		resent = append(resent, sr)
+	}
+
+	if len(failed) > 0 {
+		return resent, failed
+	}
+
+	return resent, nil
+}
+
+// SendWithFallback sends a fax at each of the given qualities in turn, waiting for each to reach a
+// terminal status, until one is delivered. This suits receiving machines that fail at higher
+// resolutions but succeed at lower ones, so qualities is typically in descending order, such as
+// QualitySuperfine then QualityStandard. The Client's other send options are used for every
+// attempt. It returns the final response of the last fax sent, whether delivered or not; use
+// IsFailure on its parsed status to tell. ErrMissingQuality is returned if qualities is empty.
+func (c *Client) SendWithFallback(to, from, mediaURL string, qualities []qualityType) (*SendResponse, error) {
+	if len(qualities) == 0 {
+		return nil, ErrMissingQuality
+	}
+
+	ctx := context.Background()
+
+	var sr *SendResponse
+	for _, q := range qualities {
+		so := c.sendOptsSnapshot()
+		so.Quality = q
+
+		queued, err := c.send(ctx, to, from, mediaURL, &so)
+		if err != nil {
+			return nil, err
+		}
+
+		sr, err = c.WaitForTerminalBackoff(ctx, queued.SID, pollMin, pollMax)
+		if err != nil {
+			return nil, err
+		}
+		if sr.Status == StatusDelivered.String() {
+			break
+		}
+	}
+
+	return sr, nil
+}
+
+// cached returns a copy of the fax with the given SID from the cache, if caching is enabled and
+// the fax was cached and hasn't expired.
+func (c *Client) cached(sid string) (*SendResponse, bool) {
+	if c.CacheTTL <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.cache[sid]
+	if !ok {
+		return nil, false
+	}
+	if c.clock().Now().After(e.expires) {
+		delete(c.cache, sid)
+		return nil, false
+	}
+
+	return e.sr.clone(), true
+}
+
+// cacheFax adds a deep copy of sr to the cache under the given SID, if caching is enabled.
+func (c *Client) cacheFax(sid string, sr *SendResponse) {
+	if c.CacheTTL <= 0 {
+		return
+	}
+
+	ttl := c.CacheTTL
+	if st, ok := parseStatus(sr.Status); ok && st.IsTerminal() {
+		ttl *= terminalCacheFactor
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock().Now()
+
+	// Faxes that are never looked up again would otherwise stay cached forever, so expired ones are
+	// pruned, at most once per CacheTTL to keep inserts cheap.
+	if now.Sub(c.cachePruned) >= c.CacheTTL {
+		for k, e := range c.cache {
+			if now.After(e.expires) {
+				delete(c.cache, k)
+			}
+		}
+		c.cachePruned = now
+	}
+
+	if c.cache == nil {
+		c.cache = map[string]cacheEntry{}
+	}
+	c.cache[sid] = cacheEntry{sr: *sr.clone(), expires: now.Add(ttl)}
+}
+
+// uncache removes the fax with the given SID from the cache.
+func (c *Client) uncache(sid string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.cache, sid)
+}
+
+// LastResponseHeader returns a copy of the headers of the most recent response received by the
+// Client, or nil if no response has been received yet.
+func (c *Client) LastResponseHeader() http.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lastHeader.Clone()
+}
+
+// ServerTiming returns the time Twilio reported spending on the most recent request, taken from
+// the Twilio-Request-Duration header of its response, for performance monitoring. It returns false
+// if no response has been received, or if the response carried no valid such header.
+func (c *Client) ServerTiming() (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	secs, err := strconv.ParseFloat(c.lastHeader.Get(requestDurationHeader), 64)
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+
+	return time.Duration(secs * float64(time.Second)), true
+}
+
+// RateLimitHeader returns the Twilio-Ratelimit-* headers of the most recent response received by
+// the Client, which can be used to throttle requests before Twilio begins rejecting them. It
+// returns an empty http.Header if the response carried no such headers.
+func (c *Client) RateLimitHeader() http.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h := http.Header{}
+	for k, v := range c.lastHeader {
+		if strings.HasPrefix(k, rateLimitHeaderPrefix) {
+			h[k] = append([]string(nil), v...)
+		}
+	}
+
+	return h
+}
+
+// SendNoStore initiates a fax like Send, using the Client's send options but with StoreMedia forced
+// to false, so that Twilio doesn't retain a copy of the media once the fax has been sent. The
+// Client's send options are left unmodified.
+func (c *Client) SendNoStore(to, from, mediaURL string) (*SendResponse, error) {
+	opts := c.sendOptsSnapshot()
+	opts.StoreMedia = false
+
+	return c.Send(to, from, mediaURL, &opts)
+}
+
+// DownloadMediaFresh writes the media of a single fax instance, by its SID, to w. Media URLs
+// expire, so rather than relying on a previously retrieved (and possibly stale) URL, it first
+// retrieves the fax, bypassing the cache, to obtain its current media link and then downloads from
+// it. An error of the type ErrorResponse is returned on any failure.
+func (c *Client) DownloadMediaFresh(sid string, w io.Writer) error {
+	res, err := c.openMedia(sid)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, err = io.Copy(w, res.Body)
+	return err
+}
+
+// MediaURL returns the URL of the media of the fax with the given SID, in the form
+// "https://fax.twilio.com/v1/Faxes/{sid}/Media", without making a request, so that it can be handed
+// to a downstream worker. Fetching it requires the Client's credentials. It returns ErrMissingSID
+// or ErrInvalidSID if sid isn't a valid fax SID.
+func (c *Client) MediaURL(sid string) (string, error) {
+	if err := validateSID(sid, faxSIDPrefix); err != nil {
+		return "", err
+	}
+
+	return c.buildURL(path.Join(sid, "Media")).String(), nil
+}
+
+// DownloadMediaBySID writes a specific media instance of a fax, by the fax's SID and the media's
+// SID (see SendResponse.MediaSID), to w. This is useful when a fax has more than one media item. An
+// error of the type ErrorResponse is returned on any failure.
+func (c *Client) DownloadMediaBySID(faxSID, mediaSID string, w io.Writer) error {
+	if !c.authenticated() {
+		return ErrNotAuthenticated
+	}
+	if err := validateSID(faxSID, faxSIDPrefix); err != nil {
+		return err
+	}
+	if err := validateSID(mediaSID, mediaSIDPrefix); err != nil {
+		return err
+	}
+
+	u := c.buildURL(path.Join(faxSID, "Media", mediaSID))
+
+	r, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.doStream(c.mediaHTTPClient(), r)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, err = io.Copy(w, res.Body)
+	return err
+}
+
+// DownloadMediaParts downloads the media of a single fax instance, by its SID, like
+// DownloadMediaFresh, returning each part of a multipart response along with its content type. A
+// response that isn't multipart is returned as a single part. An error of the type ErrorResponse is
+// returned on any failure.
+func (c *Client) DownloadMediaParts(sid string) ([]MediaPart, error) {
+	res, err := c.openMedia(sid)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	contentType := res.Header.Get("Content-Type")
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		data, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return []MediaPart{{ContentType: contentType, Data: data}}, nil
+	}
+
+	var parts []MediaPart
+
+	mr := multipart.NewReader(res.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := ioutil.ReadAll(p)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, MediaPart{ContentType: p.Header.Get("Content-Type"), Data: data})
+	}
+
+	return parts, nil
+}
+
+// openMedia retrieves a single fax instance, by its SID, to obtain its current media link and then
+// requests the media, returning the response with its body unread. The cache is bypassed, since a
+// cached fax's media link may have expired.
+func (c *Client) openMedia(sid string) (*http.Response, error) {
+	sr, _, err := c.GetRaw(sid)
+	if err != nil {
+		return nil, err
+	}
+	if sr.Links.Media == "" {
+		return nil, ErrMissingMediaURL
+	}
+
+	r, err := http.NewRequest(http.MethodGet, sr.Links.Media, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doStream(c.mediaHTTPClient(), r)
+}
+
+// ValidateCredentials checks the format of the Client's credentials without making a request: the
+// account SID must be 34 characters beginning with "AC", and the auth token 32 characters. For a
+// Client constructed with NewClientWithAPIKey, the API key SID must be 34 characters beginning with
+// "SK", and the secret 32 characters. The returned error wraps ErrInvalidCredentials.
+func (c *Client) ValidateCredentials() error {
+	cr := c.creds()
+
+	if err := validateSID(cr.accountSID, accountSIDPrefix); err != nil {
+		return fmt.Errorf("%w: account SID must be %d characters beginning with %q", ErrInvalidCredentials, sidLength, accountSIDPrefix)
+	}
+
+	if cr.apiKeySID != "" {
+		if err := validateSID(cr.apiKeySID, apiKeySIDPrefix); err != nil {
+			return fmt.Errorf("%w: API key SID must be %d characters beginning with %q", ErrInvalidCredentials, sidLength, apiKeySIDPrefix)
+		}
+		if len(cr.apiKeySecret) != secretLength {
+			return fmt.Errorf("%w: API key secret must be %d characters", ErrInvalidCredentials, secretLength)
+		}
+
+		return nil
+	}
+
+	if len(cr.authToken) != secretLength {
+		return fmt.Errorf("%w: auth token must be %d characters", ErrInvalidCredentials, secretLength)
+	}
+
+	return nil
+}
+
+// SetCredentials replaces the Client's account SID and auth token, such as after the token is
+// rotated, without disturbing its HTTP client and connection pool. Requests already in flight are
+// unaffected; subsequent requests use the new credentials. Any API key the Client was constructed
+// with is discarded.
+func (c *Client) SetCredentials(accountSID, authToken string) {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+
+	c.accountSID = accountSID
+	c.authToken = authToken
+	c.apiKeySID = ""
+	c.apiKeySecret = ""
+}
+
+// credentials describes a Client's credentials at one point in time.
+type credentials struct {
+	accountSID   string
+	authToken    string
+	apiKeySID    string
+	apiKeySecret string
+}
+
+// creds returns a copy of the Client's current credentials.
+func (c *Client) creds() credentials {
+	c.credMu.RLock()
+	defer c.credMu.RUnlock()
+
+	return credentials{
+		accountSID:   c.accountSID,
+		authToken:    c.authToken,
+		apiKeySID:    c.apiKeySID,
+		apiKeySecret: c.apiKeySecret,
+	}
+}
+
+// basicAuth returns the username and password used to authenticate requests: the API key SID and
+// secret if the Client was constructed with NewClientWithAPIKey, or the account SID and auth token
+// otherwise.
+func (c *Client) basicAuth() (username, password string) {
+	return c.creds().basicAuth()
+}
+
+// basicAuth returns the username and password described by cr; see Client.basicAuth.
+func (cr credentials) basicAuth() (username, password string) {
+	if cr.apiKeySID != "" {
+		return cr.apiKeySID, cr.apiKeySecret
+	}
+
+	return cr.accountSID, cr.authToken
+}
+
+// authenticated reports whether the Client has an AuthProvider, or an account SID and a complete
+// set of credentials.
+func (c *Client) authenticated() bool {
+	if c.AuthProvider != nil {
+		return true
+	}
+
+	cr := c.creds()
+	username, password := cr.basicAuth()
+	return cr.accountSID != "" && username != "" && password != ""
+}
+
+func (c *Client) buildURL(param string) *url.URL {
+	u := url.URL{}
+	u.Scheme = scheme
+	u.Host = host
+	u.Path = path.Join(c.apiVersion, endpoint, param)
+	return &u
+}
+
+// newTransport returns a new *http.Transport based on net/http's default transport, taking its
+// proxy from the environment.
+func newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+
+	return t
+}
+
+// cloneTransport returns a copy of the Client's HTTP transport to modify, or a new one from
+// newTransport if it's nil, in which case net/http would use its default transport. It reports false
+// if the transport is of any other type, which can't be copied.
+func (c *Client) cloneTransport() (*http.Transport, bool) {
+	switch t := c.HTTPClient.Transport.(type) {
+	case nil:
+		return newTransport(), true
+	case *http.Transport:
+		return t.Clone(), true
+	}
+
+	return nil, false
+}
+
+// mediaHTTPClient returns a copy of the Client's HTTP client for downloading media, which Twilio
+// serves by redirecting to temporary storage. The copy follows at most maxMediaRedirects redirects,
+// and never forwards credentials to a host other than the one originally requested. Its timeout is
+// the Client's MediaTimeout, if that's longer than the HTTP client's own.
+func (c *Client) mediaHTTPClient() *http.Client {
+	hc := *c.HTTPClient
+	if extendsTimeout(hc.Timeout, c.MediaTimeout) {
+		hc.Timeout = c.MediaTimeout
+	}
+	hc.CheckRedirect = func(r *http.Request, via []*http.Request) error {
+		if len(via) > maxMediaRedirects {
+			return ErrTooManyRedirects
+		}
+		if r.URL.Host != via[0].URL.Host {
+			r.Header.Del("Authorization")
+		}
+
+		return nil
+	}
+
+	return &hc
+}
+
+// extendsTimeout reports whether d lengthens the HTTP client timeout hc. An HTTP client timeout of
+// zero is no timeout at all, so nothing lengthens it.
+func extendsTimeout(hc, d time.Duration) bool {
+	return hc > 0 && d > hc
+}
+
+// contentType returns the Content-Type to set on the form-encoded bodies of requests.
+func (c *Client) contentType() string {
+	if c.ContentType != "" {
+		return c.ContentType
+	}
+
+	return DefaultContentType
+}
+
+// sendHTTPClient returns the Client's HTTP client for sends: a copy whose timeout is the Client's
+// SendTimeout, if that's longer than the HTTP client's own, or otherwise the HTTP client itself.
+func (c *Client) sendHTTPClient() *http.Client {
+	if !extendsTimeout(c.HTTPClient.Timeout, c.SendTimeout) {
+		return c.HTTPClient
+	}
+
+	hc := *c.HTTPClient
+	hc.Timeout = c.SendTimeout
+
+	return &hc
+}
+
+// DoRaw performs an arbitrary request, such as one to a Twilio fax API resource this package doesn't
+// wrap, with the Client's credentials and error handling. It returns the success response body as a
+// byte slice, or an error of the type ErrorResponse.
+func (c *Client) DoRaw(r *http.Request) ([]byte, error) {
+	if !c.authenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	return c.do(r)
+}
+
+// do performs the actual request, setting authentication credentials and returning either a success
+// response body as a byte slice or an error of type ErrorResponse.
+func (c *Client) do(r *http.Request) ([]byte, error) {
+	return c.doWith(c.HTTPClient, r)
+}
+
+// doWith performs the actual request like do, but using the HTTP client hc.
+func (c *Client) doWith(hc *http.Client, r *http.Request) ([]byte, error) {
+	res, err := c.doStream(hc, r)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, contextErr(r, err)
+	}
+
+	return body, nil
+}
+
+// contextErr returns the error of r's context if it's done, since a non-nil err is then a
+// consequence of it, or otherwise err. Returning the context's error itself, rather than the
+// transport's wrapping of it, lets callers match it with errors.Is.
+func contextErr(r *http.Request, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := r.Context().Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	return err
+}
+
+// doStream performs the actual request like do using the HTTP client hc, but on success returns the
+// response with its body unread, leaving the caller responsible for closing it.
+func (c *Client) doStream(hc *http.Client, r *http.Request) (*http.Response, error) {
+	if c.StartSpan == nil {
+		res, err := c.roundTrip(hc, r)
+		return res, contextErr(r, err)
+	}
+
+	ctx, span := c.StartSpan(r.Context(), spanName(r))
+	defer span.End()
+
+	span.SetAttribute("http.method", r.Method)
+	if sid := pathSID(r.URL.Path); sid != "" {
+		span.SetAttribute("fax.sid", sid)
+	}
+
+	res, err := c.roundTrip(hc, r.WithContext(ctx))
+	if status := statusCode(res, err); status != 0 {
+		span.SetAttribute("http.status_code", status)
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	return res, contextErr(r, err)
+}
+
+// roundTrip implements doStream, sending the request with the Client's credentials and decoding
+// any error response.
+func (c *Client) roundTrip(hc *http.Client, r *http.Request) (*http.Response, error) {
+	if c.AuthProvider != nil {
+		sid, token, err := c.AuthProvider()
+		if err != nil {
+			return nil, err
+		}
+		r.SetBasicAuth(sid, token)
+	} else {
+		r.SetBasicAuth(c.basicAuth())
+	}
+
+	// Request compression explicitly rather than leaving it to the transport, which only decompresses
+	// transparently when it added the header itself; see decompress.
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	if c.RequestMutator != nil {
+		if err := c.RequestMutator(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.TraceHook != nil {
+		var t tracer
+		r = t.trace(r)
+		defer func() { c.TraceHook(t.summary()) }()
+	}
+
+	res, err := hc.Do(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decompress(res); err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.lastHeader = res.Header
+	c.mu.Unlock()
+
+	// 304 NOT MODIFIED is only returned for a conditional request, such as one made by
+	// GetIfModifiedSince, and has no body.
+	if res.StatusCode == http.StatusNotModified {
+		res.Body.Close()
+		return nil, ErrNotModified
+	}
+
+	// Twilio returns 201 CREATED for fax resources created successfully via a POST request, 200 OK
+	// when retrieving resources via a GET request and 204 NO CONTENT when updating resources via a
 	// DELETE request. All other status codes indicate an error, in which the response body is
-	// described by ErrorResponse.
+	// described by ErrorResponse. Bodies that aren't JSON, or are JSON without any of its fields,
+	// such as those returned by a gateway or proxy, are described by HTTPError instead.
 	if res.StatusCode >= 400 {
-		var errRes ErrorResponse
-		if err := json.Unmarshal(body, &errRes); err != nil {
+		defer res.Body.Close()
+
+		body, err := ioutil.ReadAll(res.Body)
+		if err != nil {
 			return nil, err
 		}
 
+		if c.ErrorDecoder != nil {
+			if err := c.ErrorDecoder(body, res.StatusCode); err != nil {
+				return nil, err
+			}
+		}
+
+		var errRes ErrorResponse
+		if err := json.Unmarshal(body, &errRes); err != nil || errRes == (ErrorResponse{}) {
+			return nil, newHTTPError(res.StatusCode, body)
+		}
+		if errRes.Status == 0 {
+			errRes.Status = res.StatusCode
+		}
+
 		return nil, &errRes
 	}
 
-	return body, nil
+	return res, nil
+}
+
+// gzipReadCloser reads a gzip-compressed response body, closing the underlying body on Close.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+// Close satisfies the io.Closer interface.
+func (g *gzipReadCloser) Close() error {
+	g.Reader.Close()
+	return g.body.Close()
+}
+
+// decompress replaces the body of a gzip-encoded response with one that reads the decompressed
+// content. Responses with any other (or no) content encoding are left as they are, as are those
+// without a body, such as a 204 NO CONTENT or 304 NOT MODIFIED, whose Content-Encoding describes
+// the content they'd otherwise have had.
+func decompress(res *http.Response) error {
+	if !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") || !hasBody(res) {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(res.Body)
+	if err != nil {
+		return err
+	}
+
+	res.Body = &gzipReadCloser{Reader: zr, body: res.Body}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+
+	return nil
+}
+
+// hasBody reports whether res can have a body: one not in response to a HEAD request, not of a
+// status that's bodiless by definition, and not declared empty by its Content-Length.
+func hasBody(res *http.Response) bool {
+	if res.Request != nil && res.Request.Method == http.MethodHead {
+		return false
+	}
+
+	switch {
+	case res.StatusCode >= 100 && res.StatusCode < 200,
+		res.StatusCode == http.StatusNoContent,
+		res.StatusCode == http.StatusNotModified:
+		return false
+	}
+
+	return res.ContentLength != 0
 }
@@ -2,12 +2,22 @@ package fox
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"os/exec"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -159,6 +169,13 @@ func init() {
 	c = NewClient(accountSID, authToken)
 }
 
+// roundTripFunc adapts a function to the http.RoundTripper interface.
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
 func makeServer(h http.HandlerFunc) *httptest.Server {
 	server := httptest.NewServer(h)
 
@@ -190,301 +207,2470 @@ func TestNewClient(t *testing.T) {
 		assert.Equal(QualitySuperfine, got.SendOpts.Quality)
 	})
 
+	t.Run("WithHTTPClient", func(t *testing.T) {
+		var used bool
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(getResponseJSON))
+		}))
+		defer server.Close()
+
+		hc := &http.Client{
+			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				used = true
+				return c.HTTPClient.Transport.RoundTrip(r)
+			}),
+		}
+
+		got := NewClient(sid, token, WithHTTPClient(hc), &SendOpts{Quality: QualitySuperfine})
+		assert.Same(hc, got.HTTPClient)
+		assert.Equal(time.Duration(0), got.HTTPClient.Timeout)
+		assert.Equal(QualitySuperfine, got.SendOpts.Quality)
+
+		_, err := got.Get(faxSID)
+		assert.NoError(err)
+		assert.True(used)
+	})
+
 	t.Run("NoOpts", func(t *testing.T) {
 		got := NewClient(sid, token)
 		assert.Equal(sid, got.accountSID)
 		assert.Equal(token, got.authToken)
-		assert.Equal(DefaultSendOpts, got.SendOpts)
+		assert.Equal(DefaultSendOpts(), got.SendOpts)
 	})
 }
 
-func TestClient_buildURL(t *testing.T) {
+func TestDefaultSendOpts(t *testing.T) {
 	assert := assert.New(t)
 
-	t.Run("NoParam", func(t *testing.T) {
-		want := fmt.Sprintf("%s://%s/%s/%s", scheme, host, version, endpoint)
-		got := c.buildURL("").String()
-		assert.Equal(want, got)
-	})
+	c1 := NewClient("SID", "TOKEN")
+	c2 := NewClient("SID", "TOKEN")
 
-	t.Run("WithParam", func(t *testing.T) {
-		want := fmt.Sprintf("%s://%s/%s/%s/%s", scheme, host, version, endpoint, "PARAM")
-		got := c.buildURL("PARAM").String()
-		assert.Equal(want, got)
-	})
+	c1.SendOpts.Quality = QualitySuperfine
+	c1.SendOpts.StoreMedia = false
+
+	assert.Equal(QualityFine, c2.SendOpts.Quality)
+	assert.True(c2.SendOpts.StoreMedia)
+	assert.Equal(QualityFine, DefaultSendOpts().Quality)
+	assert.True(DefaultSendOpts().StoreMedia)
 }
 
-func TestClient_do(t *testing.T) {
+func TestNewClientStrict(t *testing.T) {
 	assert := assert.New(t)
 
+	const token = "0123456789abcdef0123456789abcdef"
+
 	t.Run("OK", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.Write([]byte("OK"))
-		}))
-		defer server.Close()
+		got, err := NewClientStrict(accountSID, token)
+		assert.NoError(err)
+		assert.Equal(accountSID, got.accountSID)
+	})
 
-		r, err := http.NewRequest(http.MethodGet, server.URL, nil)
-		if err != nil {
-			t.Error(err)
-			t.FailNow()
+	t.Run("ErrInvalidCredentials", func(t *testing.T) {
+		for _, tc := range []struct{ sid, token string }{
+			{"", token},
+			{"AC123", token},
+			{"FXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX", token},
+			{accountSID + "X", token},
+			{accountSID, ""},
+			{accountSID, "short"},
+		} {
+			got, err := NewClientStrict(tc.sid, tc.token)
+			assert.True(errors.Is(err, ErrInvalidCredentials), tc)
+			assert.Nil(got)
 		}
-
-		got, err := c.do(r)
-		assert.NoError(err)
-		assert.True(bytes.Equal([]byte("OK"), got))
 	})
+}
 
-	t.Run("Error", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.WriteHeader(http.StatusNotFound)
-			w.Write([]byte("ERROR"))
-		}))
-		defer server.Close()
+func TestClient_ValidateCredentials(t *testing.T) {
+	assert := assert.New(t)
 
-		r, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	const secret = "0123456789abcdef0123456789abcdef"
 
-		_, err = c.do(r)
-		assert.Error(err)
+	t.Run("APIKey", func(t *testing.T) {
+		assert.NoError(NewClientWithAPIKey(accountSID, "SKXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX", secret).ValidateCredentials())
+
+		err := NewClientWithAPIKey(accountSID, "KEY_SID", secret).ValidateCredentials()
+		assert.EqualError(err, `fox: credentials are malformed: API key SID must be 34 characters beginning with "SK"`)
+
+		err = NewClientWithAPIKey(accountSID, "SKXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX", "").ValidateCredentials()
+		assert.EqualError(err, "fox: credentials are malformed: API key secret must be 32 characters")
+	})
+
+	t.Run("AccountSID", func(t *testing.T) {
+		err := NewClient("SID", secret).ValidateCredentials()
+		assert.EqualError(err, `fox: credentials are malformed: account SID must be 34 characters beginning with "AC"`)
 	})
 }
 
-func TestClient_Cancel(t *testing.T) {
+func TestNewClientWithAPIKey(t *testing.T) {
 	assert := assert.New(t)
 
-	t.Run("OK", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.Write([]byte(deleteResponseJSON))
-		}))
-		defer server.Close()
+	got := NewClientWithAPIKey("SID", "KEY_SID", "KEY_SECRET")
+	assert.Equal("SID", got.accountSID)
+	assert.Equal("", got.authToken)
+	assert.Equal("KEY_SID", got.apiKeySID)
+	assert.Equal("KEY_SECRET", got.apiKeySecret)
+	assert.Equal(DefaultSendOpts(), got.SendOpts)
 
-		assert.NoError(c.Cancel(faxSID))
-	})
+	t.Run("BasicAuth", func(t *testing.T) {
+		var username, password string
 
-	t.Run("ErrorResponse", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.WriteHeader(http.StatusConflict)
-			w.Write([]byte(errorResponseJSON))
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, _ = r.BasicAuth()
+			w.Write([]byte(getResponseJSON))
 		}))
 		defer server.Close()
 
-		assert.Error(c.Cancel(faxSID))
+		got.HTTPClient = c.HTTPClient
+
+		_, err := got.Get(faxSID)
+		assert.NoError(err)
+		assert.Equal("KEY_SID", username)
+		assert.Equal("KEY_SECRET", password)
 	})
 
 	t.Run("ErrNotAuthenticated", func(t *testing.T) {
-		currentSID := c.accountSID
-		currentToken := c.authToken
-
-		defer func() {
-			c.accountSID = currentSID
-			c.authToken = currentToken
-		}()
-
-		c.accountSID = ""
-		c.authToken = ""
-
-		assert.Equal(ErrNotAuthenticated, c.Cancel(faxSID))
-	})
+		_, err := NewClientWithAPIKey("SID", "KEY_SID", "").Get(faxSID)
+		assert.Equal(ErrNotAuthenticated, err)
 
-	t.Run("ErrMissingSID", func(t *testing.T) {
-		assert.Equal(ErrMissingSID, c.Cancel(""))
+		_, err = NewClientWithAPIKey("", "KEY_SID", "KEY_SECRET").Get(faxSID)
+		assert.Equal(ErrNotAuthenticated, err)
 	})
 }
 
-func TestClient_Delete(t *testing.T) {
+func TestWithProxy(t *testing.T) {
 	assert := assert.New(t)
 
+	t.Run("Default", func(t *testing.T) {
+		transport, ok := NewClient("SID", "TOKEN").HTTPClient.Transport.(*http.Transport)
+		if !ok {
+			t.Error("transport is not an *http.Transport")
+			t.FailNow()
+		}
+
+		assert.Equal(reflect.ValueOf(http.ProxyFromEnvironment).Pointer(), reflect.ValueOf(transport.Proxy).Pointer())
+	})
+
 	t.Run("OK", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.WriteHeader(http.StatusNoContent)
-			w.Write([]byte("ANY"))
+		var proxied string
+
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = r.URL.String()
+			w.Write([]byte(getResponseJSON))
 		}))
-		defer server.Close()
+		defer proxy.Close()
 
-		assert.NoError(c.Delete(faxSID))
+		currentScheme, currentHost := scheme, host
+		defer func() { scheme, host = currentScheme, currentHost }()
+		scheme, host = "http", "fax.twilio.com"
+
+		got := NewClient(accountSID, authToken, WithProxy(proxy.URL))
+
+		_, err := got.Get(faxSID)
+		assert.NoError(err)
+		assert.Equal(got.buildURL(faxSID).String(), proxied)
 	})
 
-	t.Run("ErrorResponse", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.WriteHeader(http.StatusConflict)
-			w.Write([]byte(errorResponseJSON))
-		}))
-		defer server.Close()
+	t.Run("InvalidURL", func(t *testing.T) {
+		got := NewClient(accountSID, authToken, WithProxy("http://[::1"))
 
-		assert.Error(c.Delete(faxSID))
+		_, err := got.Get(faxSID)
+		assert.Error(err)
 	})
 
-	t.Run("ErrNotAuthenticated", func(t *testing.T) {
-		currentSID := c.accountSID
-		currentToken := c.authToken
+	t.Run("WithHTTPClient", func(t *testing.T) {
+		transport := &http.Transport{}
+		hc := &http.Client{Transport: transport, Timeout: time.Second}
 
-		defer func() {
-			c.accountSID = currentSID
-			c.authToken = currentToken
-		}()
+		got := NewClient(accountSID, authToken, WithHTTPClient(hc), WithProxy("http://proxy.example.com"))
 
-		c.accountSID = ""
-		c.authToken = ""
+		assert.Same(transport, hc.Transport)
+		assert.Nil(transport.Proxy)
+		assert.NotSame(hc, got.HTTPClient)
+		assert.Equal(time.Second, got.HTTPClient.Timeout)
+	})
 
-		assert.Equal(ErrNotAuthenticated, c.Delete(faxSID))
+	t.Run("UnsupportedTransport", func(t *testing.T) {
+		hc := &http.Client{Transport: roundTripFunc(http.DefaultTransport.RoundTrip)}
+
+		got := NewClient(accountSID, authToken, WithHTTPClient(hc), WithProxy("http://proxy.example.com"))
+		assert.Same(hc, got.HTTPClient)
 	})
 
-	t.Run("ErrMissingSID", func(t *testing.T) {
-		assert.Equal(ErrMissingSID, c.Delete(""))
+	// net/http reads the proxy environment variables only once per process, so the client under test
+	// runs in a copy of the test binary with them set.
+	t.Run("Environment", func(t *testing.T) {
+		if os.Getenv("FOX_TEST_PROXY_CHILD") != "" {
+			currentScheme, currentHost := scheme, host
+			defer func() { scheme, host = currentScheme, currentHost }()
+			scheme, host = "http", "fax.twilio.com"
+
+			_, err := NewClient(accountSID, authToken).Get(faxSID)
+			assert.NoError(err)
+			return
+		}
+
+		var proxied string
+
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = r.URL.String()
+			w.Write([]byte(getResponseJSON))
+		}))
+		defer proxy.Close()
+
+		cmd := exec.Command(os.Args[0], "-test.run=^TestWithProxy$/^Environment$")
+		cmd.Env = append(os.Environ(), "FOX_TEST_PROXY_CHILD=1", "HTTP_PROXY="+proxy.URL, "NO_PROXY=", "no_proxy=")
+
+		out, err := cmd.CombinedOutput()
+		assert.NoError(err, string(out))
+		assert.Equal(fmt.Sprintf("http://fax.twilio.com/%s/%s/%s", version, endpoint, faxSID), proxied)
 	})
 }
 
-func TestClient_Get(t *testing.T) {
+func TestClient_SetCredentials(t *testing.T) {
 	assert := assert.New(t)
 
-	t.Run("OK", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.Write([]byte(getResponseJSON))
-		}))
-		defer server.Close()
+	var mu sync.Mutex
+	var passwords []string
 
-		got, err := c.Get(faxSID)
-		assert.NoError(err)
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, password, _ := r.BasicAuth()
 
-		if got == nil {
-			t.Error("got is nil")
-			t.FailNow()
-		}
+		mu.Lock()
+		passwords = append(passwords, password)
+		mu.Unlock()
 
-		assert.Equal("delivered", got.Status)
-		assert.Equal("fine", got.Quality)
-	})
+		w.Write([]byte(getResponseJSON))
+	}))
+	defer server.Close()
 
-	t.Run("ErrorResponse", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.WriteHeader(http.StatusNotFound)
-			w.Write([]byte(errorResponseJSON))
-		}))
-		defer server.Close()
+	t.Run("Rotate", func(t *testing.T) {
+		rc := c.Clone()
 
-		_, err := c.Get(faxSID)
-		assert.Error(err)
-	})
+		_, err := rc.Get(faxSID)
+		assert.NoError(err)
 
-	t.Run("ErrNotAuthenticated", func(t *testing.T) {
-		currentSID := c.accountSID
-		currentToken := c.authToken
+		rc.SetCredentials(accountSID, "ROTATED_TOKEN")
 
-		defer func() {
-			c.accountSID = currentSID
-			c.authToken = currentToken
-		}()
+		_, err = rc.Get(faxSID)
+		assert.NoError(err)
+		assert.Equal([]string{authToken, "ROTATED_TOKEN"}, passwords)
+	})
 
-		c.accountSID = ""
-		c.authToken = ""
+	t.Run("DiscardsAPIKey", func(t *testing.T) {
+		rc := NewClientWithAPIKey(accountSID, "KEY_SID", "KEY_SECRET")
+		rc.SetCredentials(accountSID, "ROTATED_TOKEN")
 
-		_, err := c.Get(faxSID)
-		assert.Equal(ErrNotAuthenticated, err)
+		username, password := rc.basicAuth()
+		assert.Equal(accountSID, username)
+		assert.Equal("ROTATED_TOKEN", password)
 	})
 
-	t.Run("ErrMissingSID", func(t *testing.T) {
-		_, err := c.Get("")
-		assert.Equal(ErrMissingSID, err)
+	t.Run("Concurrent", func(t *testing.T) {
+		rc := c.Clone()
+
+		// Run with -race: rotating credentials must be safe while requests are in flight.
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rc.Get(faxSID)
+			}()
+		}
+		for i := 0; i < 10; i++ {
+			rc.SetCredentials(accountSID, fmt.Sprintf("TOKEN_%d", i))
+		}
+		wg.Wait()
 	})
 }
 
-func TestClient_List(t *testing.T) {
+func TestClient_AuthProvider(t *testing.T) {
 	assert := assert.New(t)
 
-	t.Run("OK", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.Write([]byte(listResponseJSON))
-		}))
-		defer server.Close()
+	var requests int
+	var usernames, passwords []string
 
-		got, err := c.List()
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
 
-		assert.NoError(err)
-		assert.Len(got.Faxes, 1)
-		assert.Equal(got.Meta.PageSize, 50)
-	})
+		username, password, _ := r.BasicAuth()
+		usernames = append(usernames, username)
+		passwords = append(passwords, password)
 
-	t.Run("Error", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.WriteHeader(http.StatusForbidden)
-			w.Write([]byte(errorResponseJSON))
-		}))
-		defer server.Close()
+		w.Write([]byte(getResponseJSON))
+	}))
+	defer server.Close()
 
-		_, err := c.List()
-		assert.Error(err)
+	t.Run("Rotating", func(t *testing.T) {
+		var calls int
+
+		pc := NewClient("", "")
+		pc.AuthProvider = func() (string, string, error) {
+			calls++
+			return accountSID, fmt.Sprintf("TOKEN_%d", calls), nil
+		}
+
+		for i := 0; i < 3; i++ {
+			_, err := pc.Get(faxSID)
+			assert.NoError(err)
+		}
+
+		assert.Equal([]string{accountSID, accountSID, accountSID}, usernames)
+		assert.Equal([]string{"TOKEN_1", "TOKEN_2", "TOKEN_3"}, passwords)
 	})
 
-	t.Run("ErrNotAuthenticated", func(t *testing.T) {
-		currentSID := c.accountSID
-		currentToken := c.authToken
+	t.Run("Error", func(t *testing.T) {
+		errVault := errors.New("vault is sealed")
 
-		defer func() {
-			c.accountSID = currentSID
-			c.authToken = currentToken
-		}()
+		pc := c.Clone()
+		pc.AuthProvider = func() (string, string, error) {
+			return "", "", errVault
+		}
 
-		c.accountSID = ""
-		c.authToken = ""
+		requests = 0
 
-		_, err := c.List()
-		assert.Equal(ErrNotAuthenticated, err)
+		_, err := pc.Get(faxSID)
+		assert.True(errors.Is(err, errVault))
+		assert.Equal(0, requests)
 	})
 }
 
-func TestClient_Send(t *testing.T) {
+func TestClient_Clone(t *testing.T) {
 	assert := assert.New(t)
 
-	t.Run("OK", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.Write([]byte(sendResponseJSON))
-		}))
-		defer server.Close()
+	orig := NewClientWithAPIKey("SID", "KEY_SID", "KEY_SECRET", WithAPIVersion("v2"))
+	orig.CacheTTL = time.Minute
 
-		got, err := c.Send(to, from, faxMediaURL)
+	got := orig.Clone()
+	assert.Same(orig.HTTPClient, got.HTTPClient)
+	assert.Equal(orig.SendOpts, got.SendOpts)
+	assert.NotSame(orig.SendOpts, got.SendOpts)
+	assert.Equal(orig.buildURL("PARAM"), got.buildURL("PARAM"))
+	assert.Equal(time.Minute, got.CacheTTL)
 
-		assert.NoError(err)
-		assert.Equal(got.Status, "queued")
-	})
+	username, password := got.basicAuth()
+	assert.Equal("KEY_SID", username)
+	assert.Equal("KEY_SECRET", password)
 
-	t.Run("Error", func(t *testing.T) {
-		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.WriteHeader(http.StatusForbidden)
-			w.Write([]byte(errorResponseJSON))
-		}))
-		defer server.Close()
+	got.SendOpts.Quality = QualitySuperfine
+	got.SendOpts.StoreMedia = false
 
-		_, err := c.Send(to, from, faxMediaURL)
-		assert.Error(err)
-	})
+	assert.Equal(QualityFine, orig.SendOpts.Quality)
+	assert.True(orig.SendOpts.StoreMedia)
 
-	t.Run("ErrNotAuthenticated", func(t *testing.T) {
-		currentSID := c.accountSID
-		currentToken := c.authToken
+	t.Run("Extra", func(t *testing.T) {
+		orig.SendOpts.Extra = url.Values{"NewParam": {"a"}}
 
-		defer func() {
-			c.accountSID = currentSID
-			c.authToken = currentToken
-		}()
+		got := orig.Clone()
+		got.SendOpts.Extra.Set("NewParam", "b")
 
-		c.accountSID = ""
-		c.authToken = ""
+		assert.Equal("a", orig.SendOpts.Extra.Get("NewParam"))
+	})
 
-		_, err := c.Send(to, from, faxMediaURL)
-		assert.Equal(ErrNotAuthenticated, err)
+	t.Run("NilSendOpts", func(t *testing.T) {
+		nc := NewClient("SID", "TOKEN")
+		nc.SendOpts = nil
+
+		assert.Nil(nc.Clone().SendOpts)
 	})
 
-	t.Run("ErrMissingToNumber", func(t *testing.T) {
-		_, err := c.Send("", from, faxMediaURL)
-		assert.Equal(ErrMissingToNumber, err)
+	t.Run("ConcurrentSetSendOpts", func(t *testing.T) {
+		cc := NewClient("SID", "TOKEN")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+				cc.SetSendOpts(&SendOpts{Quality: QualitySuperfine})
+			}()
+			go func() {
+				defer wg.Done()
+				assert.NotNil(cc.Clone().SendOpts)
+			}()
+		}
+		wg.Wait()
 	})
+}
 
-	t.Run("ErrMissingFromNumber", func(t *testing.T) {
-		_, err := c.Send(to, "", faxMediaURL)
-		assert.Equal(ErrMissingFromNumber, err)
+func TestClient_SetTransportConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	got := NewClient("SID", "TOKEN")
+	err := got.SetTransportConfig(TransportConfig{
+		MaxIdleConns:        200,
+		MaxIdleConnsPerHost: 50,
 	})
+	assert.NoError(err)
 
-	t.Run("ErrMissingMediaURL", func(t *testing.T) {
+	transport, ok := got.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Error("transport is not an *http.Transport")
+		t.FailNow()
+	}
+
+	assert.Equal(200, transport.MaxIdleConns)
+	assert.Equal(50, transport.MaxIdleConnsPerHost)
+	assert.Equal(http.DefaultTransport.(*http.Transport).IdleConnTimeout, transport.IdleConnTimeout)
+	assert.Equal(DefaultTimeoutDuration, got.HTTPClient.Timeout)
+
+	t.Run("Clone", func(t *testing.T) {
+		original := NewClient("SID", "TOKEN")
+		hc, transport := original.HTTPClient, original.HTTPClient.Transport
+
+		clone := original.Clone()
+		assert.NoError(clone.SetTransportConfig(TransportConfig{MaxIdleConnsPerHost: 50}))
+
+		assert.Same(hc, original.HTTPClient)
+		assert.Same(transport, original.HTTPClient.Transport)
+		assert.NotEqual(50, original.HTTPClient.Transport.(*http.Transport).MaxIdleConnsPerHost)
+		assert.Equal(50, clone.HTTPClient.Transport.(*http.Transport).MaxIdleConnsPerHost)
+	})
+
+	t.Run("NilTransport", func(t *testing.T) {
+		nc := NewClient("SID", "TOKEN", WithHTTPClient(&http.Client{}))
+		assert.NoError(nc.SetTransportConfig(TransportConfig{MaxIdleConnsPerHost: 50}))
+		assert.Equal(50, nc.HTTPClient.Transport.(*http.Transport).MaxIdleConnsPerHost)
+	})
+
+	t.Run("ErrUnsupportedTransport", func(t *testing.T) {
+		hc := &http.Client{Transport: roundTripFunc(http.DefaultTransport.RoundTrip)}
+
+		uc := NewClient("SID", "TOKEN", WithHTTPClient(hc))
+		assert.Equal(ErrUnsupportedTransport, uc.SetTransportConfig(TransportConfig{MaxIdleConnsPerHost: 50}))
+		assert.Same(hc, uc.HTTPClient)
+	})
+}
+
+func TestClient_buildURL(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("NoParam", func(t *testing.T) {
+		want := fmt.Sprintf("%s://%s/%s/%s", scheme, host, version, endpoint)
+		got := c.buildURL("").String()
+		assert.Equal(want, got)
+	})
+
+	t.Run("WithParam", func(t *testing.T) {
+		want := fmt.Sprintf("%s://%s/%s/%s/%s", scheme, host, version, endpoint, "PARAM")
+		got := c.buildURL("PARAM").String()
+		assert.Equal(want, got)
+	})
+
+	t.Run("TestMode", func(t *testing.T) {
+		tc := NewClient(accountSID, authToken)
+		tc.TestMode = true
+
+		want := c.buildURL("PARAM").String()
+		got := tc.buildURL("PARAM").String()
+		assert.Equal(want, got)
+		assert.True(tc.TestMode)
+		assert.False(c.TestMode)
+	})
+
+	t.Run("WithAPIVersion", func(t *testing.T) {
+		want := fmt.Sprintf("%s://%s/%s/%s/%s", scheme, host, "v2", endpoint, "PARAM")
+		got := NewClient(accountSID, authToken, WithAPIVersion("v2")).buildURL("PARAM").String()
+		assert.Equal(want, got)
+	})
+
+	t.Run("WithEmptyAPIVersion", func(t *testing.T) {
+		want := fmt.Sprintf("%s://%s/%s/%s/%s", scheme, host, version, endpoint, "PARAM")
+		got := NewClient(accountSID, authToken, WithAPIVersion("")).buildURL("PARAM").String()
+		assert.Equal(want, got)
+	})
+}
+
+func TestClient_MediaURL(t *testing.T) {
+	assert := assert.New(t)
+
+	got, err := c.MediaURL(faxSID)
+	assert.NoError(err)
+	assert.Equal(c.buildURL(faxSID).String()+"/Media", got)
+	assert.Equal(fmt.Sprintf("%s://%s/%s/%s/%s/Media", scheme, host, version, endpoint, faxSID), got)
+
+	_, err = c.MediaURL("")
+	assert.Equal(ErrMissingSID, err)
+
+	_, err = c.MediaURL("MEXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+	assert.Equal(ErrInvalidSID, err)
+}
+
+func TestClient_do(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte("OK"))
+		}))
+		defer server.Close()
+
+		r, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		got, err := c.do(r)
+		assert.NoError(err)
+		assert.True(bytes.Equal([]byte("OK"), got))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("ERROR"))
+		}))
+		defer server.Close()
+
+		r, err := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		_, err = c.do(r)
+		assert.Error(err)
+	})
+
+	t.Run("NonJSONError", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html><body><h1>502 Bad Gateway</h1></body></html>"))
+		}))
+		defer server.Close()
+
+		r, err := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		_, err = c.do(r)
+		if !assert.IsType(&HTTPError{}, err) {
+			t.FailNow()
+		}
+
+		assert.Equal(http.StatusBadGateway, err.(*HTTPError).StatusCode)
+		assert.Contains(err.Error(), "502")
+		assert.Contains(err.Error(), "Bad Gateway")
+	})
+
+	t.Run("ForeignJSONError", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "no route to fax"}`))
+		}))
+		defer server.Close()
+
+		r, err := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		_, err = c.do(r)
+		if !assert.IsType(&HTTPError{}, err) {
+			t.FailNow()
+		}
+
+		assert.Equal(http.StatusNotFound, err.(*HTTPError).StatusCode)
+		assert.Contains(err.Error(), "no route to fax")
+		assert.True(errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("ErrorWithoutStatus", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": 20404, "message": "The requested resource was not found"}`))
+		}))
+		defer server.Close()
+
+		r, err := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		_, err = c.do(r)
+		if !assert.IsType(&ErrorResponse{}, err) {
+			t.FailNow()
+		}
+
+		assert.Equal(http.StatusNotFound, err.(*ErrorResponse).Status)
+		assert.True(err.(*ErrorResponse).IsNotFound())
+		assert.True(errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		started := make(chan struct{})
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-started
+			cancel()
+		}()
+
+		r, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		_, err = c.do(r)
+		assert.True(errors.Is(err, context.Canceled))
+	})
+}
+
+func TestClient_DoRaw(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		var username string
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, _, _ = r.BasicAuth()
+			w.Write([]byte(`{"media": []}`))
+		}))
+		defer server.Close()
+
+		r, err := http.NewRequest(http.MethodGet, c.buildURL(faxSID+"/Media").String(), nil)
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		got, err := c.DoRaw(r)
+		assert.NoError(err)
+		assert.Equal(`{"media": []}`, string(got))
+		assert.Equal(c.accountSID, username)
+	})
+
+	t.Run("ErrorResponse", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorResponseJSON))
+		}))
+		defer server.Close()
+
+		r, err := http.NewRequest(http.MethodGet, c.buildURL(faxSID+"/Media").String(), nil)
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		_, err = c.DoRaw(r)
+		if assert.IsType(&ErrorResponse{}, err) {
+			assert.Equal(1228, err.(*ErrorResponse).Code)
+			assert.Equal(404, err.(*ErrorResponse).Status)
+		}
+	})
+
+	t.Run("ErrNotAuthenticated", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, c.buildURL("").String(), nil)
+
+		_, err := NewClient("", "").DoRaw(r)
+		assert.Equal(ErrNotAuthenticated, err)
+	})
+}
+
+func TestClient_Cancel(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(deleteResponseJSON))
+		}))
+		defer server.Close()
+
+		got, err := c.Cancel(faxSID)
+		assert.NoError(err)
+
+		if got == nil {
+			t.Error("got is nil")
+			t.FailNow()
+		}
+
+		assert.Equal("canceled", got.Status)
+		assert.Equal(faxSID, got.SID)
+	})
+
+	t.Run("NoContent", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		got, err := c.Cancel(faxSID)
+		assert.NoError(err)
+		assert.Equal(&SendResponse{SID: faxSID, Status: "canceled"}, got)
+		assert.NoError(c.CancelSID(faxSID))
+	})
+
+	t.Run("NoContentGzip", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		got, err := c.Cancel(faxSID)
+		assert.NoError(err)
+		assert.Equal(&SendResponse{SID: faxSID, Status: "canceled"}, got)
+	})
+
+	t.Run("CancelSID", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(deleteResponseJSON))
+		}))
+		defer server.Close()
+
+		assert.NoError(c.CancelSID(faxSID))
+	})
+
+	t.Run("ErrorResponse", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(errorResponseJSON))
+		}))
+		defer server.Close()
+
+		_, err := c.Cancel(faxSID)
+		assert.Error(err)
+	})
+
+	t.Run("ErrNotAuthenticated", func(t *testing.T) {
+		currentSID := c.accountSID
+		currentToken := c.authToken
+
+		defer func() {
+			c.accountSID = currentSID
+			c.authToken = currentToken
+		}()
+
+		c.accountSID = ""
+		c.authToken = ""
+
+		_, err := c.Cancel(faxSID)
+		assert.Equal(ErrNotAuthenticated, err)
+	})
+
+	t.Run("ErrMissingSID", func(t *testing.T) {
+		_, err := c.Cancel("")
+		assert.Equal(ErrMissingSID, err)
+	})
+}
+
+func TestClient_CancelTo(t *testing.T) {
+	assert := assert.New(t)
+
+	const other = "+15550000000"
+
+	t.Run("OK", func(t *testing.T) {
+		var filter string
+		var canceled []string
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				canceled = append(canceled, path.Base(r.URL.Path))
+				w.Write([]byte(deleteResponseJSON))
+				return
+			}
+
+			filter = r.URL.Query().Get("To")
+
+			fmt.Fprintf(w, `{"faxes": [
+				{"sid": "FX1", "to": "%[1]s", "status": "queued"},
+				{"sid": "FX2", "to": "%[1]s", "status": "delivered"},
+				{"sid": "FX3", "to": "%[1]s", "status": "sending"},
+				{"sid": "FX4", "to": "%[1]s", "status": "failed"},
+				{"sid": "FX5", "to": "%[2]s", "status": "queued"}
+			], "meta": {}}`, to, other)
+		}))
+		defer server.Close()
+
+		got, err := c.CancelTo(context.Background(), to)
+		assert.NoError(err)
+		assert.Equal(to, filter)
+		assert.Equal([]string{"FX1", "FX3"}, got)
+		assert.Equal([]string{"FX1", "FX3"}, canceled)
+	})
+
+	t.Run("ErrorResponse", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				if path.Base(r.URL.Path) == "FX2" {
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(errorResponseJSON))
+					return
+				}
+				w.Write([]byte(deleteResponseJSON))
+				return
+			}
+
+			fmt.Fprintf(w, `{"faxes": [
+				{"sid": "FX1", "to": "%[1]s", "status": "queued"},
+				{"sid": "FX2", "to": "%[1]s", "status": "queued"},
+				{"sid": "FX3", "to": "%[1]s", "status": "queued"}
+			], "meta": {}}`, to)
+		}))
+		defer server.Close()
+
+		got, err := c.CancelTo(context.Background(), to)
+		assert.IsType(&ErrorResponse{}, err)
+		assert.Equal([]string{"FX1"}, got)
+	})
+
+	t.Run("ErrMissingToNumber", func(t *testing.T) {
+		_, err := c.CancelTo(context.Background(), "")
+		assert.Equal(ErrMissingToNumber, err)
+	})
+}
+
+func TestClient_Delete(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+			w.Write([]byte("ANY"))
+		}))
+		defer server.Close()
+
+		assert.NoError(c.Delete(faxSID))
+	})
+
+	t.Run("ErrorResponse", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(errorResponseJSON))
+		}))
+		defer server.Close()
+
+		assert.Error(c.Delete(faxSID))
+	})
+
+	t.Run("ErrNotAuthenticated", func(t *testing.T) {
+		currentSID := c.accountSID
+		currentToken := c.authToken
+
+		defer func() {
+			c.accountSID = currentSID
+			c.authToken = currentToken
+		}()
+
+		c.accountSID = ""
+		c.authToken = ""
+
+		assert.Equal(ErrNotAuthenticated, c.Delete(faxSID))
+	})
+
+	t.Run("ErrMissingSID", func(t *testing.T) {
+		assert.Equal(ErrMissingSID, c.Delete(""))
+	})
+}
+
+func TestClient_Get(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(getResponseJSON))
+		}))
+		defer server.Close()
+
+		got, err := c.Get(faxSID)
+		assert.NoError(err)
+
+		if got == nil {
+			t.Error("got is nil")
+			t.FailNow()
+		}
+
+		assert.Equal("delivered", got.Status)
+		assert.Equal("fine", got.Quality)
+		assert.Equal(QualityFine, got.ParsedQuality)
+	})
+
+	t.Run("ErrorResponse", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorResponseJSON))
+		}))
+		defer server.Close()
+
+		_, err := c.Get(faxSID)
+		assert.Error(err)
+	})
+
+	t.Run("ResponseHeader", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Twilio-Ratelimit-Remaining", "42")
+			w.Header().Set("Twilio-Request-Id", "RQXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+			w.Write([]byte(getResponseJSON))
+		}))
+		defer server.Close()
+
+		_, err := c.Get(faxSID)
+		assert.NoError(err)
+
+		assert.Equal("42", c.LastResponseHeader().Get("Twilio-Ratelimit-Remaining"))
+		assert.Equal("RQXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX", c.LastResponseHeader().Get("Twilio-Request-Id"))
+		assert.Equal(http.Header{"Twilio-Ratelimit-Remaining": {"42"}}, c.RateLimitHeader())
+	})
+
+	t.Run("ServerTiming", func(t *testing.T) {
+		duration := "0.037"
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if duration != "" {
+				w.Header().Set("Twilio-Request-Duration", duration)
+			}
+			w.Write([]byte(getResponseJSON))
+		}))
+		defer server.Close()
+
+		_, err := c.Get(faxSID)
+		assert.NoError(err)
+
+		got, ok := c.ServerTiming()
+		assert.True(ok)
+		assert.Equal(37*time.Millisecond, got)
+
+		duration = ""
+		_, err = c.Get(faxSID)
+		assert.NoError(err)
+
+		_, ok = c.ServerTiming()
+		assert.False(ok)
+	})
+
+	t.Run("ErrNotAuthenticated", func(t *testing.T) {
+		currentSID := c.accountSID
+		currentToken := c.authToken
+
+		defer func() {
+			c.accountSID = currentSID
+			c.authToken = currentToken
+		}()
+
+		c.accountSID = ""
+		c.authToken = ""
+
+		_, err := c.Get(faxSID)
+		assert.Equal(ErrNotAuthenticated, err)
+	})
+
+	t.Run("ErrMissingSID", func(t *testing.T) {
+		_, err := c.Get("")
+		assert.Equal(ErrMissingSID, err)
+	})
+}
+
+func TestClient_CacheTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			requests++
+			w.Write([]byte(sendResponseJSON))
+			return
+		}
+
+		w.Write([]byte(deleteResponseJSON))
+	}))
+	defer server.Close()
+
+	c.CacheTTL = time.Minute
+	defer func() {
+		c.CacheTTL = 0
+		c.cache = nil
+	}()
+
+	t.Run("Hit", func(t *testing.T) {
+		first, err := c.Get(faxSID)
+		assert.NoError(err)
+
+		second, err := c.Get(faxSID)
+		assert.NoError(err)
+
+		assert.Equal(1, requests)
+		assert.Equal(first, second)
+		assert.NotSame(first, second)
+	})
+
+	t.Run("InvalidatedByCancel", func(t *testing.T) {
+		_, err := c.Cancel(faxSID)
+		assert.NoError(err)
+
+		_, err = c.Get(faxSID)
+		assert.NoError(err)
+		assert.Equal(2, requests)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		c.mu.Lock()
+		e := c.cache[faxSID]
+		e.expires = time.Now().Add(-time.Second)
+		c.cache[faxSID] = e
+		c.mu.Unlock()
+
+		_, err := c.Get(faxSID)
+		assert.NoError(err)
+		assert.Equal(3, requests)
+	})
+
+	t.Run("Terminal", func(t *testing.T) {
+		c.cacheFax("FXTERMINAL", &SendResponse{Status: StatusDelivered.String()})
+
+		c.mu.Lock()
+		expires := c.cache["FXTERMINAL"].expires
+		c.mu.Unlock()
+
+		assert.True(time.Until(expires) > c.CacheTTL)
+	})
+
+	t.Run("Pruned", func(t *testing.T) {
+		fc := &fakeClock{now: time.Now()}
+
+		pc := c.Clone()
+		pc.Clock = fc
+
+		for _, sid := range []string{"FX1", "FX2", "FX3"} {
+			pc.cacheFax(sid, &SendResponse{Status: StatusSending.String()})
+		}
+
+		fc.Advance(pc.CacheTTL + time.Second)
+		pc.cacheFax("FX4", &SendResponse{Status: StatusSending.String()})
+
+		pc.mu.Lock()
+		defer pc.mu.Unlock()
+
+		assert.Len(pc.cache, 1)
+		assert.Contains(pc.cache, "FX4")
+	})
+
+	t.Run("DeepCopy", func(t *testing.T) {
+		price, numPages := "-0.0070", 2
+		in := &SendResponse{Price: &price, NumPages: &numPages, Links: Links{Other: map[string]string{"a": "b"}}}
+		c.cacheFax("FXCOPY", in)
+
+		*in.Price = "1.00"
+		in.Links.Other["a"] = "c"
+
+		got, ok := c.cached("FXCOPY")
+		if assert.True(ok) {
+			assert.Equal("-0.0070", *got.Price)
+			assert.Equal("b", got.Links.Other["a"])
+
+			*got.NumPages = 5
+			got.Links.Other["a"] = "d"
+		}
+
+		again, _ := c.cached("FXCOPY")
+		assert.Equal(2, *again.NumPages)
+		assert.Equal("b", again.Links.Other["a"])
+	})
+
+	t.Run("DownloadMediaFresh", func(t *testing.T) {
+		var faxRequests int
+
+		var server *httptest.Server
+		server = makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/"+version+"/") {
+				faxRequests++
+				fmt.Fprintf(w, `{"sid": "%s", "links": {"media": "%s/Media%d"}}`, faxSID, server.URL, faxRequests)
+				return
+			}
+
+			w.Write([]byte(r.URL.Path))
+		}))
+		defer server.Close()
+
+		c.uncache(faxSID)
+
+		_, err := c.Get(faxSID)
+		assert.NoError(err)
+
+		var buf bytes.Buffer
+		assert.NoError(c.DownloadMediaFresh(faxSID, &buf))
+		assert.Equal(2, faxRequests)
+		assert.Equal("/Media2", buf.String())
+	})
+}
+
+func TestClient_GetErrorClassification(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[int]error{
+		http.StatusBadRequest:   ErrBadRequest,
+		http.StatusUnauthorized: ErrUnauthorized,
+		http.StatusNotFound:     ErrNotFound,
+	}
+
+	for status, want := range tests {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"code": 20000, "message": "%s", "status": %d}`, http.StatusText(status), status)
+		}))
+
+		_, err := c.Get(faxSID)
+		server.Close()
+
+		assert.True(errors.Is(err, want), status)
+		for _, other := range tests {
+			if other != want {
+				assert.False(errors.Is(err, other), status)
+			}
+		}
+	}
+}
+
+func TestClient_ErrorDecoder(t *testing.T) {
+	assert := assert.New(t)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error": {"reason": "upstream unavailable"}}`))
+	}))
+	defer server.Close()
+
+	errGateway := errors.New("gateway error")
+
+	var gotBody string
+	var gotStatus int
+
+	dc := c.Clone()
+	dc.ErrorDecoder = func(body []byte, statusCode int) error {
+		gotBody, gotStatus = string(body), statusCode
+		return errGateway
+	}
+
+	_, err := dc.Get(faxSID)
+	assert.Equal(errGateway, err)
+	assert.Equal(`{"error": {"reason": "upstream unavailable"}}`, gotBody)
+	assert.Equal(http.StatusInternalServerError, gotStatus)
+
+	_, err = c.Get(faxSID)
+	assert.IsType(&HTTPError{}, err)
+
+	t.Run("Nil", func(t *testing.T) {
+		nc := c.Clone()
+		nc.ErrorDecoder = func([]byte, int) error { return nil }
+
+		_, err := nc.Get(faxSID)
+		if assert.IsType(&HTTPError{}, err) {
+			assert.Equal(http.StatusInternalServerError, err.(*HTTPError).StatusCode)
+		}
+
+		var span *fakeSpan
+		nc.StartSpan = func(ctx context.Context, name string) (context.Context, Span) {
+			span = &fakeSpan{name: name, attrs: map[string]interface{}{}}
+			return ctx, span
+		}
+
+		_, err = nc.Get(faxSID)
+		assert.IsType(&HTTPError{}, err)
+		if assert.NotNil(span) {
+			assert.True(span.ended)
+			assert.Equal(http.StatusInternalServerError, span.attrs["http.status_code"])
+		}
+	})
+}
+
+func TestClient_RequestMutator(t *testing.T) {
+	assert := assert.New(t)
+
+	var gotPath, gotAuth string
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		w.Write([]byte(getResponseJSON))
+	}))
+	defer server.Close()
+
+	t.Run("RewritePath", func(t *testing.T) {
+		mc := c.Clone()
+		mc.RequestMutator = func(r *http.Request) error {
+			assert.NotEmpty(r.Header.Get("Authorization"))
+
+			r.URL.Path = "/cache" + r.URL.Path
+			r.Header.Del("Authorization")
+			return nil
+		}
+
+		_, err := mc.Get(faxSID)
+		assert.NoError(err)
+		assert.Equal("/cache/"+version+"/"+endpoint+"/"+faxSID, gotPath)
+		assert.Empty(gotAuth)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		gotPath = ""
+		errAbort := errors.New("abort")
+
+		mc := c.Clone()
+		mc.RequestMutator = func(r *http.Request) error { return errAbort }
+
+		_, err := mc.Get(faxSID)
+		assert.Equal(errAbort, err)
+		assert.Empty(gotPath)
+	})
+}
+
+func TestClient_GetRaw(t *testing.T) {
+	assert := assert.New(t)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(getResponseJSON))
+	}))
+	defer server.Close()
+
+	t.Run("OK", func(t *testing.T) {
+		got, raw, err := c.GetRaw(faxSID)
+		assert.NoError(err)
+		assert.Equal(getResponseJSON, string(raw))
+		assert.Equal(faxSID, got.SID)
+		assert.Equal("delivered", got.Status)
+	})
+
+	t.Run("ErrMissingSID", func(t *testing.T) {
+		_, _, err := c.GetRaw("")
+		assert.Equal(ErrMissingSID, err)
+	})
+}
+
+func TestClient_GetIfModifiedSince(t *testing.T) {
+	assert := assert.New(t)
+
+	updated := time.Date(2026, time.March, 2, 15, 4, 5, 0, time.UTC)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if err == nil && !updated.After(since) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		fmt.Fprintf(w, `{"sid": "%s", "status": "delivered", "date_updated": "%s"}`, faxSID, updated.Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	t.Run("Modified", func(t *testing.T) {
+		got, err := c.GetIfModifiedSince(faxSID, updated.Add(-time.Minute))
+		assert.NoError(err)
+		assert.Equal(faxSID, got.SID)
+		assert.True(updated.Equal(got.DateUpdated))
+	})
+
+	t.Run("NotModified", func(t *testing.T) {
+		got, err := c.GetIfModifiedSince(faxSID, updated)
+		assert.Equal(ErrNotModified, err)
+		assert.Nil(got)
+	})
+
+	t.Run("ErrMissingSID", func(t *testing.T) {
+		_, err := c.GetIfModifiedSince("", updated)
+		assert.Equal(ErrMissingSID, err)
+	})
+
+	t.Run("NotModifiedGzip", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.WriteHeader(http.StatusNotModified)
+		}))
+		defer server.Close()
+
+		got, err := c.GetIfModifiedSince(faxSID, updated)
+		assert.Equal(ErrNotModified, err)
+		assert.Nil(got)
+	})
+}
+
+func TestClient_GetMany(t *testing.T) {
+	assert := assert.New(t)
+
+	sids := []string{
+		"FX0000000000000000000000000000000A",
+		"FX0000000000000000000000000000000B",
+		"FX0000000000000000000000000000000A",
+		"FX0000000000000000000000000000000C",
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var mu sync.Mutex
+		requests := 0
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requests++
+			mu.Unlock()
+
+			sid := path.Base(r.URL.Path)
+			if sid == "FX0000000000000000000000000000000C" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(errorResponseJSON))
+				return
+			}
+
+			fmt.Fprintf(w, `{"sid": "%s", "status": "delivered"}`, sid)
+		}))
+		defer server.Close()
+
+		got, errs := c.GetMany(context.Background(), sids, 2)
+
+		assert.Equal(3, requests)
+		assert.Len(got, 2)
+		assert.Equal("FX0000000000000000000000000000000A", got["FX0000000000000000000000000000000A"].SID)
+		assert.Equal("FX0000000000000000000000000000000B", got["FX0000000000000000000000000000000B"].SID)
+		assert.Len(errs, 1)
+		assert.IsType(&ErrorResponse{}, errs["FX0000000000000000000000000000000C"])
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		got, errs := c.GetMany(ctx, sids, 2)
+
+		assert.Empty(got)
+		assert.Len(errs, 3)
+		for _, err := range errs {
+			assert.True(errors.Is(err, context.Canceled))
+		}
+	})
+}
+
+func TestClient_WaitForTerminalBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		polls := 0
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			polls++
+
+			status := "sending"
+			if polls == 4 {
+				status = "delivered"
+			}
+
+			fmt.Fprintf(w, `{"sid": "%s", "status": "%s"}`, faxSID, status)
+		}))
+		defer server.Close()
+
+		start := time.Now()
+		got, err := c.WaitForTerminalBackoff(context.Background(), faxSID, time.Millisecond, 4*time.Millisecond)
+
+		assert.NoError(err)
+		assert.Equal("delivered", got.Status)
+		assert.Equal(4, polls)
+		assert.True(time.Since(start) >= 7*time.Millisecond) // 1ms + 2ms + 4ms between polls
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		polls := 0
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			fmt.Fprintf(w, `{"sid": "%s", "status": "sending"}`, faxSID)
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := c.WaitForTerminalBackoff(ctx, faxSID, 20*time.Millisecond, time.Second)
+
+		assert.Equal(context.DeadlineExceeded, err)
+		assert.True(polls <= 3)
+	})
+
+	t.Run("InvalidBackoff", func(t *testing.T) {
+		_, err := c.WaitForTerminalBackoff(context.Background(), faxSID, 0, time.Second)
+		assert.Equal(ErrInvalidBackoff, err)
+
+		_, err = c.WaitForTerminalBackoff(context.Background(), faxSID, time.Second, time.Millisecond)
+		assert.Equal(ErrInvalidBackoff, err)
+	})
+}
+
+func TestClient_List(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(listResponseJSON))
+		}))
+		defer server.Close()
+
+		got, err := c.List()
+
+		assert.NoError(err)
+		assert.Len(got.Faxes, 1)
+		assert.Equal(got.Meta.PageSize, 50)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(errorResponseJSON))
+		}))
+		defer server.Close()
+
+		_, err := c.List()
+		assert.Error(err)
+	})
+
+	t.Run("CombinedFilters", func(t *testing.T) {
+		var data url.Values
+		var body []byte
+		var contentType string
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data = r.URL.Query()
+			body, _ = ioutil.ReadAll(r.Body)
+			contentType = r.Header.Get("Content-Type")
+			w.Write([]byte(listResponseJSON))
+		}))
+		defer server.Close()
+
+		after := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+		before := after.AddDate(0, 1, 0)
+
+		_, err := c.List(&ListOpts{
+			DateCreatedAfter:      after,
+			DateCreatedOnOrBefore: before,
+			From:                  from,
+			To:                    to,
+		})
+		assert.NoError(err)
+
+		assert.Equal(url.Values{
+			"DateCreatedAfter":      {"2021-03-01T00:00:00Z"},
+			"DateCreatedOnOrBefore": {"2021-04-01T00:00:00Z"},
+			"From":                  {from},
+			"To":                    {to},
+		}, data)
+		assert.Empty(body)
+		assert.Empty(contentType)
+	})
+
+	t.Run("Extra", func(t *testing.T) {
+		var data url.Values
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data = r.URL.Query()
+			w.Write([]byte(listResponseJSON))
+		}))
+		defer server.Close()
+
+		_, err := c.List(&ListOpts{
+			To: to,
+			Extra: url.Values{
+				"PageSize": {"20"},
+				"To":       {"+15550000000"},
+			},
+		})
+		assert.NoError(err)
+
+		assert.Equal(url.Values{
+			"PageSize": {"20"},
+			"To":       {to},
+		}, data)
+	})
+
+	t.Run("Gzip", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal("gzip", r.Header.Get("Accept-Encoding"))
+
+			w.Header().Set("Content-Encoding", "gzip")
+
+			zw := gzip.NewWriter(w)
+			zw.Write([]byte(listResponseJSON))
+			zw.Close()
+		}))
+		defer server.Close()
+
+		got, err := c.List()
+
+		assert.NoError(err)
+		assert.Len(got.Faxes, 1)
+		assert.Equal("", c.LastResponseHeader().Get("Content-Encoding"))
+	})
+
+	t.Run("ErrNotAuthenticated", func(t *testing.T) {
+		currentSID := c.accountSID
+		currentToken := c.authToken
+
+		defer func() {
+			c.accountSID = currentSID
+			c.authToken = currentToken
+		}()
+
+		c.accountSID = ""
+		c.authToken = ""
+
+		_, err := c.List()
+		assert.Equal(ErrNotAuthenticated, err)
+	})
+}
+
+func TestClient_ListAll(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int
+
+	var server *httptest.Server
+	server = makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		page := r.URL.Query().Get("Page")
+		if page == "" {
+			page = "0"
+		}
+
+		next := "null"
+		if page == "0" {
+			next = fmt.Sprintf(`"%s/%s/%s?PageSize=2&Page=1"`, server.URL, version, endpoint)
+		}
+
+		fmt.Fprintf(w, `{
+			"faxes": [{"sid": "FX%s0"}, {"sid": "FX%s1"}],
+			"meta": {"page": %s, "page_size": 2, "next_page_url": %s}
+		}`, page, page, page, next)
+	}))
+	defer server.Close()
+
+	t.Run("All", func(t *testing.T) {
+		requests = 0
+
+		got, err := c.ListAll(nil)
+		assert.NoError(err)
+		assert.Equal(2, requests)
+
+		if assert.Len(got, 4) {
+			assert.Equal("FX00", got[0].SID)
+			assert.Equal("FX11", got[3].SID)
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		requests = 0
+
+		got, err := c.ListAll(&ListOpts{Limit: 1})
+		assert.NoError(err)
+		assert.Equal(1, requests)
+
+		if assert.Len(got, 1) {
+			assert.Equal("FX00", got[0].SID)
+		}
+	})
+
+	t.Run("LimitAcrossPages", func(t *testing.T) {
+		requests = 0
+
+		got, err := c.ListAll(&ListOpts{Limit: 3})
+		assert.NoError(err)
+		assert.Equal(2, requests)
+		assert.Len(got, 3)
+	})
+}
+
+func TestClient_NextPage(t *testing.T) {
+	assert := assert.New(t)
+
+	var server *httptest.Server
+	server = makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("Page") == "1" {
+			w.Write([]byte(`{"faxes": [{"sid": "FX10"}], "meta": {"page": 1, "next_page_url": null}}`))
+			return
+		}
+
+		fmt.Fprintf(w, `{"faxes": [{"sid": "FX00"}], "meta": {"page": 0, "next_page_url": "%s/%s/%s?Page=1"}}`, server.URL, version, endpoint)
+	}))
+	defer server.Close()
+
+	first, err := c.List()
+	assert.NoError(err)
+	assert.True(first.HasNext())
+
+	second, err := c.NextPage(first)
+	assert.NoError(err)
+	assert.Equal(1, second.Meta.Page)
+	assert.Equal("FX10", second.Faxes[0].SID)
+	assert.False(second.HasNext())
+
+	_, err = c.NextPage(second)
+	assert.Equal(ErrNoNextPage, err)
+}
+
+func TestClient_ListEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, body := range []string{
+		`{"faxes": [], "meta": {"page": 0}}`,
+		`{"faxes": null, "meta": {"page": 0}}`,
+		`{"meta": {"page": 0}}`,
+	} {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		}))
+
+		got, err := c.List()
+		server.Close()
+
+		assert.NoError(err, body)
+		assert.NotNil(got.Faxes, body)
+		assert.Len(got.Faxes, 0, body)
+		assert.True(got.IsEmpty(), body)
+	}
+}
+
+// listPayload returns a list response body holding n faxes.
+func listPayload(n int) []byte {
+	var b bytes.Buffer
+	b.WriteString(`{"faxes": [`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"sid": "FX%032d", "account_sid": "%s", "from": "%s", "to": "%s", "quality": "fine", "status": "delivered", "direction": "outbound", "num_pages": 2, "duration": 60, "price": "-0.0070", "price_unit": "USD", "date_created": "2015-07-30T20:00:00Z", "date_updated": "2015-07-30T20:00:00Z", "media_url": "%s", "links": {"media": "https://fax.twilio.com/v1/Faxes/FX%032d/Media"}, "url": "https://fax.twilio.com/v1/Faxes/FX%032d"}`, i, accountSID, from, to, faxMediaURL, i, i)
+	}
+	b.WriteString(`], "meta": {"page": 0, "page_size": 1000, "key": "faxes"}}`)
+
+	return b.Bytes()
+}
+
+func BenchmarkList(b *testing.B) {
+	payload := listPayload(1000)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		lr, err := c.List()
+		if err != nil || len(lr.Faxes) != 1000 {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestClient_ListContext(t *testing.T) {
+	assert := assert.New(t)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+			w.Write([]byte(listResponseJSON))
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	got, err := c.ListContext(ctx)
+
+	assert.Equal(context.DeadlineExceeded, err)
+	assert.Nil(got)
+	assert.True(time.Since(start) < 500*time.Millisecond)
+}
+
+func TestClient_ListSince(t *testing.T) {
+	assert := assert.New(t)
+
+	var data url.Values
+	var body []byte
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data = r.URL.Query()
+		body, _ = ioutil.ReadAll(r.Body)
+		w.Write([]byte(listResponseJSON))
+	}))
+	defer server.Close()
+
+	opts := ListOpts{To: to}
+
+	got, err := c.ListSince(24*time.Hour, &opts)
+	assert.NoError(err)
+	assert.Len(got.Faxes, 1)
+
+	after, err := time.Parse(time.RFC3339, data.Get("DateCreatedAfter"))
+	assert.NoError(err)
+	assert.WithinDuration(time.Now().Add(-24*time.Hour), after, 5*time.Second)
+	assert.Equal(to, data.Get("To"))
+	assert.Len(data, 2)
+	assert.Empty(body)
+	assert.True(opts.DateCreatedAfter.IsZero())
+}
+
+func TestClient_Send(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(sendResponseJSON))
+		}))
+		defer server.Close()
+
+		got, err := c.Send(to, from, faxMediaURL)
+
+		assert.NoError(err)
+		assert.Equal(got.Status, "queued")
+		assert.Nil(got.NumPages)
+		assert.Nil(got.Price)
+		assert.Nil(got.Duration)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(errorResponseJSON))
+		}))
+		defer server.Close()
+
+		_, err := c.Send(to, from, faxMediaURL)
+		assert.Error(err)
+	})
+
+	t.Run("ErrMediaTooLarge", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"code": %d, "message": "Media too large", "status": 400}`, codeMediaTooLarge)
+		}))
+		defer server.Close()
+
+		_, err := c.Send(to, from, faxMediaURL)
+		assert.True(errors.Is(err, ErrMediaTooLarge))
+		assert.IsType(&ErrorResponse{}, err)
+	})
+
+	t.Run("ErrNotAuthenticated", func(t *testing.T) {
+		currentSID := c.accountSID
+		currentToken := c.authToken
+
+		defer func() {
+			c.accountSID = currentSID
+			c.authToken = currentToken
+		}()
+
+		c.accountSID = ""
+		c.authToken = ""
+
+		_, err := c.Send(to, from, faxMediaURL)
+		assert.Equal(ErrNotAuthenticated, err)
+	})
+
+	t.Run("ErrMissingToNumber", func(t *testing.T) {
+		_, err := c.Send("", from, faxMediaURL)
+		assert.Equal(ErrMissingToNumber, err)
+	})
+
+	t.Run("ErrMissingFromNumber", func(t *testing.T) {
+		_, err := c.Send(to, "", faxMediaURL)
+		assert.Equal(ErrMissingFromNumber, err)
+	})
+
+	t.Run("ErrMissingMediaURL", func(t *testing.T) {
 		_, err := c.Send(to, from, "")
 		assert.Equal(ErrMissingMediaURL, err)
 	})
+
+	t.Run("InvalidSendOpts", func(t *testing.T) {
+		_, err := c.Send(to, from, faxMediaURL, &SendOpts{TTLMinutes: -1})
+		assert.Equal(ErrInvalidTTL, err)
+	})
+
+	t.Run("ContentType", func(t *testing.T) {
+		var contentType string
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(sendResponseJSON))
+		}))
+		defer server.Close()
+
+		_, err := c.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+		assert.Equal(DefaultContentType, contentType)
+
+		pc := c.Clone()
+		pc.ContentType = "application/x-www-form-urlencoded"
+
+		_, err = pc.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+		assert.Equal("application/x-www-form-urlencoded", contentType)
+	})
+
+	t.Run("RetriedBody", func(t *testing.T) {
+		var bodies []string
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(sendResponseJSON))
+		}))
+		defer server.Close()
+
+		// The transport consumes the body of the first attempt and fails it, then retries with a body
+		// rewound by GetBody, as a retrying transport would.
+		inner := c.HTTPClient.Transport
+
+		rc := c.Clone()
+		rc.HTTPClient = &http.Client{
+			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				ioutil.ReadAll(r.Body)
+				r.Body.Close()
+
+				if r.GetBody == nil {
+					return nil, errors.New("request body can't be rewound")
+				}
+				body, err := r.GetBody()
+				if err != nil {
+					return nil, err
+				}
+
+				retry := r.Clone(r.Context())
+				retry.Body = body
+
+				return inner.RoundTrip(retry)
+			}),
+		}
+
+		_, err := rc.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+
+		if assert.Len(bodies, 1) {
+			got, err := url.ParseQuery(bodies[0])
+			assert.NoError(err)
+			assert.Equal(to, got.Get("To"))
+			assert.Equal(from, got.Get("From"))
+			assert.Equal(faxMediaURL, got.Get("MediaUrl"))
+		}
+	})
+}
+
+func TestClient_SetSendOpts(t *testing.T) {
+	assert := assert.New(t)
+
+	var mu sync.Mutex
+	var sent []url.Values
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		mu.Lock()
+		sent = append(sent, r.PostForm)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(sendResponseJSON))
+	}))
+	defer server.Close()
+
+	sc := c.Clone()
+
+	// Run with -race: SetSendOpts must be safe while sends are in flight.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sc.Send(to, from, faxMediaURL)
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		if i%2 == 0 {
+			sc.SetSendOpts(&SendOpts{Quality: QualitySuperfine, StoreMedia: false})
+		} else {
+			sc.SetSendOpts(&SendOpts{Quality: QualityStandard, StoreMedia: true})
+		}
+	}
+	wg.Wait()
+
+	// Each send used one consistent set of options.
+	assert.Len(sent, 20)
+	for _, form := range sent {
+		switch form.Get("Quality") {
+		case "superfine":
+			assert.Equal("false", form.Get("StoreMedia"))
+		case "standard", "fine":
+			assert.Equal("true", form.Get("StoreMedia"))
+		default:
+			t.Errorf("unexpected quality %q", form.Get("Quality"))
+		}
+	}
+
+	t.Run("Copies", func(t *testing.T) {
+		so := SendOpts{Quality: QualityFine}
+		sc.SetSendOpts(&so)
+		so.Quality = QualitySuperfine
+
+		assert.Equal(QualityFine, sc.SendOpts.Quality)
+	})
+}
+
+func TestClient_SendSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	got := c.SendSummary(to, from, faxMediaURL, &SendOpts{Quality: QualityFine, SIPAuthPassword: "hunter2", SIPAuthUsername: "username"})
+
+	assert.Contains(got, "To: "+to+"\n")
+	assert.Contains(got, "From: "+from+"\n")
+	assert.Contains(got, "MediaUrl: "+faxMediaURL+"\n")
+	assert.Contains(got, "Quality: fine\n")
+	assert.Contains(got, "SipAuthPassword: ********\n")
+	assert.NotContains(got, "hunter2")
+
+	assert.Contains(c.SendSummary(to, from, faxMediaURL), "Quality: "+c.SendOpts.Quality.String()+"\n")
+
+	t.Run("NilClientSendOpts", func(t *testing.T) {
+		nc := c.Clone()
+		nc.SendOpts = nil
+
+		assert.Contains(nc.SendSummary(to, from, faxMediaURL, &SendOpts{Quality: QualitySuperfine}), "Quality: superfine\n")
+	})
+}
+
+func TestClient_SendNoStore(t *testing.T) {
+	assert := assert.New(t)
+
+	var storeMedia string
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		storeMedia = r.PostForm.Get("StoreMedia")
+		w.Write([]byte(sendResponseJSON))
+	}))
+	defer server.Close()
+
+	assert.True(c.SendOpts.StoreMedia)
+
+	_, err := c.SendNoStore(to, from, faxMediaURL)
+	assert.NoError(err)
+	assert.Equal("false", storeMedia)
+	assert.True(c.SendOpts.StoreMedia)
+}
+
+func TestClient_DownloadMediaFresh(t *testing.T) {
+	assert := assert.New(t)
+
+	var mediaPath string
+
+	var server *httptest.Server
+	server = makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/"+version+"/"+endpoint+"/"+faxSID {
+			fmt.Fprintf(w, `{"sid": "%s", "links": {"media": "%s/fresh/Media"}}`, faxSID, server.URL)
+			return
+		}
+
+		mediaPath = r.URL.Path
+		w.Write([]byte("%PDF-1.4"))
+	}))
+	defer server.Close()
+
+	t.Run("OK", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(c.DownloadMediaFresh(faxSID, &buf))
+		assert.Equal("/fresh/Media", mediaPath)
+		assert.Equal("%PDF-1.4", buf.String())
+	})
+
+	t.Run("ErrMissingSID", func(t *testing.T) {
+		assert.Equal(ErrMissingSID, c.DownloadMediaFresh("", &bytes.Buffer{}))
+	})
+}
+
+func TestClient_MediaTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	var server *httptest.Server
+	server = makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/"+version+"/") {
+			fmt.Fprintf(w, `{"sid": "%s", "links": {"media": "%s/Media?delay=%s"}}`, faxSID, server.URL, path.Base(r.URL.Path))
+			return
+		}
+
+		delay, _ := time.ParseDuration(r.URL.Query().Get("delay"))
+
+		w.Write([]byte("%PDF-1.4"))
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-r.Context().Done():
+		case <-time.After(delay):
+			w.Write([]byte(" more"))
+		}
+	}))
+	defer server.Close()
+
+	// The API timeout is shorter than the delay in both cases, so only MediaTimeout applies.
+	hc := *c.HTTPClient
+	hc.Timeout = 20 * time.Millisecond
+
+	mc := c.Clone()
+	mc.HTTPClient = &hc
+	mc.MediaTimeout = 200 * time.Millisecond
+
+	t.Run("WithinTimeout", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(mc.DownloadMediaFresh("50ms", &buf))
+		assert.Equal("%PDF-1.4 more", buf.String())
+	})
+
+	t.Run("ExceedsTimeout", func(t *testing.T) {
+		assert.Error(mc.DownloadMediaFresh("5s", &bytes.Buffer{}))
+	})
+
+	t.Run("NeverShortens", func(t *testing.T) {
+		hc := *c.HTTPClient
+		hc.Timeout = 5 * time.Second
+
+		lc := c.Clone()
+		lc.HTTPClient = &hc
+		lc.MediaTimeout = 20 * time.Millisecond
+
+		var buf bytes.Buffer
+		assert.NoError(lc.DownloadMediaFresh("50ms", &buf))
+		assert.Equal("%PDF-1.4 more", buf.String())
+	})
+
+	t.Run("NoTimeout", func(t *testing.T) {
+		hc := *c.HTTPClient
+		hc.Timeout = 0
+
+		nc := c.Clone()
+		nc.HTTPClient = &hc
+		nc.MediaTimeout = 20 * time.Millisecond
+
+		assert.NoError(nc.DownloadMediaFresh("50ms", &bytes.Buffer{}))
+	})
+}
+
+func TestClient_SendTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(sendResponseJSON))
+			return
+		}
+		w.Write([]byte(getResponseJSON))
+	}))
+	defer server.Close()
+
+	// The API timeout is shorter than the server's delay, so only a send can succeed.
+	hc := *c.HTTPClient
+	hc.Timeout = 20 * time.Millisecond
+
+	sc := c.Clone()
+	sc.HTTPClient = &hc
+	sc.SendTimeout = 200 * time.Millisecond
+
+	t.Run("Send", func(t *testing.T) {
+		_, err := sc.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		_, err := sc.Get(faxSID)
+		assert.Error(err)
+	})
+
+	t.Run("NeverShortens", func(t *testing.T) {
+		hc := *c.HTTPClient
+		hc.Timeout = 5 * time.Second
+
+		lc := c.Clone()
+		lc.HTTPClient = &hc
+		lc.SendTimeout = 20 * time.Millisecond
+
+		_, err := lc.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+	})
+
+	t.Run("NoTimeout", func(t *testing.T) {
+		hc := *c.HTTPClient
+		hc.Timeout = 0
+
+		nc := c.Clone()
+		nc.HTTPClient = &hc
+		nc.SendTimeout = 20 * time.Millisecond
+
+		_, err := nc.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+	})
+}
+
+func TestClient_DownloadMediaBySID(t *testing.T) {
+	assert := assert.New(t)
+
+	media := map[string]string{
+		"ME0000000000000000000000000000000A": "%PDF-1.4 A",
+		"ME0000000000000000000000000000000B": "%PDF-1.4 B",
+	}
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dir, mediaSID := path.Split(r.URL.Path)
+		data, ok := media[mediaSID]
+		if dir != "/"+version+"/"+endpoint+"/"+faxSID+"/Media/" || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorResponseJSON))
+			return
+		}
+
+		w.Write([]byte(data))
+	}))
+	defer server.Close()
+
+	t.Run("OK", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(c.DownloadMediaBySID(faxSID, "ME0000000000000000000000000000000B", &buf))
+		assert.Equal("%PDF-1.4 B", buf.String())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		err := c.DownloadMediaBySID(faxSID, "ME0000000000000000000000000000000C", &bytes.Buffer{})
+		assert.IsType(&ErrorResponse{}, err)
+	})
+
+	t.Run("ErrMissingSID", func(t *testing.T) {
+		assert.Equal(ErrMissingSID, c.DownloadMediaBySID("", "ME0000000000000000000000000000000A", &bytes.Buffer{}))
+		assert.Equal(ErrMissingSID, c.DownloadMediaBySID(faxSID, "", &bytes.Buffer{}))
+	})
+
+	t.Run("ErrInvalidSID", func(t *testing.T) {
+		assert.Equal(ErrInvalidSID, c.DownloadMediaBySID("ME0000000000000000000000000000000A", "ME0000000000000000000000000000000A", &bytes.Buffer{}))
+		assert.Equal(ErrInvalidSID, c.DownloadMediaBySID(faxSID, "ME0", &bytes.Buffer{}))
+	})
+}
+
+func TestClient_DownloadMediaParts(t *testing.T) {
+	assert := assert.New(t)
+
+	multipartBody := "--BOUNDARY\r\n" +
+		"Content-Type: application/pdf\r\n\r\n" +
+		"%PDF-1.4\r\n" +
+		"--BOUNDARY\r\n" +
+		"Content-Type: image/tiff\r\n\r\n" +
+		"II*\r\n" +
+		"--BOUNDARY--\r\n"
+
+	var server *httptest.Server
+	server = makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + version + "/" + endpoint + "/multipart":
+			fmt.Fprintf(w, `{"sid": "multipart", "links": {"media": "%s/Multipart"}}`, server.URL)
+		case "/" + version + "/" + endpoint + "/plain":
+			fmt.Fprintf(w, `{"sid": "plain", "links": {"media": "%s/Plain"}}`, server.URL)
+		case "/Multipart":
+			w.Header().Set("Content-Type", "multipart/mixed; boundary=BOUNDARY")
+			w.Write([]byte(multipartBody))
+		default:
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4"))
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Multipart", func(t *testing.T) {
+		got, err := c.DownloadMediaParts("multipart")
+		assert.NoError(err)
+
+		if assert.Len(got, 2) {
+			assert.Equal(MediaPart{ContentType: "application/pdf", Data: []byte("%PDF-1.4")}, got[0])
+			assert.Equal(MediaPart{ContentType: "image/tiff", Data: []byte("II*")}, got[1])
+		}
+	})
+
+	t.Run("Plain", func(t *testing.T) {
+		got, err := c.DownloadMediaParts("plain")
+		assert.NoError(err)
+		assert.Equal([]MediaPart{{ContentType: "application/pdf", Data: []byte("%PDF-1.4")}}, got)
+	})
+}
+
+func TestClient_DownloadMediaFresh_Redirect(t *testing.T) {
+	assert := assert.New(t)
+
+	var mediaAuth, storageAuth string
+
+	var server *httptest.Server
+	server = makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Host == "storage.fax.twilio.com":
+			storageAuth = r.Header.Get("Authorization")
+			w.Write([]byte("%PDF-1.4"))
+		case r.URL.Path == "/"+version+"/"+endpoint+"/"+faxSID:
+			fmt.Fprintf(w, `{"sid": "%s", "links": {"media": "http://fax.twilio.com/Media"}}`, faxSID)
+		case r.URL.Path == "/"+version+"/"+endpoint+"/loop":
+			fmt.Fprintf(w, `{"sid": "loop", "links": {"media": "%s/Loop"}}`, server.URL)
+		case r.URL.Path == "/Loop":
+			http.Redirect(w, r, "/Loop", http.StatusFound)
+		default:
+			mediaAuth = r.Header.Get("Authorization")
+			// A subdomain of the original host, to which net/http alone would forward credentials.
+			http.Redirect(w, r, "http://storage.fax.twilio.com/media.pdf", http.StatusTemporaryRedirect)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("StripsAuthorization", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(c.DownloadMediaFresh(faxSID, &buf))
+		assert.Equal("%PDF-1.4", buf.String())
+		assert.NotEmpty(mediaAuth)
+		assert.Empty(storageAuth)
+	})
+
+	t.Run("ErrTooManyRedirects", func(t *testing.T) {
+		err := c.DownloadMediaFresh("loop", &bytes.Buffer{})
+		assert.True(errors.Is(err, ErrTooManyRedirects))
+	})
+}
+
+const resendListResponseJSON = `{
+	"faxes": [
+		{"sid": "FX0000000000000000000000000000000A", "status": "failed", "to": "+15558675310", "from": "+15017122661", "media_url": "https://www.example.com/a.pdf", "quality": "superfine"},
+		{"sid": "FX0000000000000000000000000000000B", "status": "busy", "to": "+15558675310", "from": "+15017122661", "media_url": null},
+		{"sid": "FX0000000000000000000000000000000C", "status": "delivered", "to": "+15558675310", "from": "+15017122661", "media_url": "https://www.example.com/c.pdf"},
+		{"sid": "FX0000000000000000000000000000000D", "status": "no-answer", "to": "+15558675310", "from": "+15017122661", "media_url": "https://www.example.com/d.pdf"}
+	],
+	"meta": {"page": 0, "page_size": 50}
+}`
+
+func TestClient_SendWithFallback(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(min, max time.Duration) {
+		pollMin, pollMax = min, max
+	}(pollMin, pollMax)
+	pollMin, pollMax = time.Millisecond, time.Millisecond
+
+	// Each fax's SID ends with its quality, which determines its final status.
+	final := map[string]string{
+		"superfine": "failed",
+		"fine":      "delivered",
+		"standard":  "delivered",
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		var sent []string
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				r.ParseForm()
+				q := r.PostForm.Get("Quality")
+				sent = append(sent, q)
+
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"sid": "FX%s", "quality": "%s", "status": "queued"}`, q, q)
+				return
+			}
+
+			q := strings.TrimPrefix(path.Base(r.URL.Path), "FX")
+			fmt.Fprintf(w, `{"sid": "FX%s", "quality": "%s", "status": "%s"}`, q, q, final[q])
+		}))
+		defer server.Close()
+
+		got, err := c.SendWithFallback(to, from, faxMediaURL, []qualityType{QualitySuperfine, QualityFine, QualityStandard})
+		assert.NoError(err)
+		assert.Equal([]string{"superfine", "fine"}, sent)
+		assert.Equal("FXfine", got.SID)
+		assert.Equal("delivered", got.Status)
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+			fmt.Fprintf(w, `{"sid": "%s", "status": "failed"}`, faxSID)
+		}))
+		defer server.Close()
+
+		got, err := c.SendWithFallback(to, from, faxMediaURL, []qualityType{QualitySuperfine, QualityFine})
+		assert.NoError(err)
+		assert.Equal("failed", got.Status)
+	})
+
+	t.Run("ErrMissingQuality", func(t *testing.T) {
+		_, err := c.SendWithFallback(to, from, faxMediaURL, nil)
+		assert.Equal(ErrMissingQuality, err)
+	})
+}
+
+func TestClient_ResendFailed(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		var sent []url.Values
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				w.Write([]byte(resendListResponseJSON))
+				return
+			}
+
+			r.ParseForm()
+			sent = append(sent, r.PostForm)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(sendResponseJSON))
+		}))
+		defer server.Close()
+
+		got, err := c.ResendFailed(context.Background(), nil)
+		assert.Len(got, 2)
+
+		if assert.Len(sent, 2) {
+			assert.Equal("https://www.example.com/a.pdf", sent[0].Get("MediaUrl"))
+			assert.Equal("superfine", sent[0].Get("Quality"))
+			assert.Equal("https://www.example.com/d.pdf", sent[1].Get("MediaUrl"))
+			assert.Equal(c.SendOpts.Quality.String(), sent[1].Get("Quality"))
+		}
+
+		if assert.IsType(ResendError{}, err) {
+			assert.Len(err, 1)
+			assert.Equal(ErrMissingMediaURL, err.(ResendError)["FX0000000000000000000000000000000B"])
+		}
+	})
+
+	t.Run("ErrorResponse", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(errorResponseJSON))
+		}))
+		defer server.Close()
+
+		got, err := c.ResendFailed(context.Background(), nil)
+		assert.Nil(got)
+		assert.IsType(&ErrorResponse{}, err)
+	})
+
+	t.Run("Pages", func(t *testing.T) {
+		var sent []string
+
+		var server *httptest.Server
+		server = makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				r.ParseForm()
+				sent = append(sent, r.PostForm.Get("MediaUrl"))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(sendResponseJSON))
+				return
+			}
+
+			page := r.URL.Query().Get("Page")
+			if page == "" {
+				page = "0"
+			}
+
+			next := "null"
+			if page == "0" {
+				next = fmt.Sprintf(`"%s/%s/%s?PageSize=1&Page=1"`, server.URL, version, endpoint)
+			}
+
+			fmt.Fprintf(w, `{
+				"faxes": [{"sid": "FX%[1]s", "to": "%[2]s", "from": "%[3]s", "status": "failed", "media_url": "https://www.example.com/%[1]s.pdf"}],
+				"meta": {"page": %[1]s, "page_size": 1, "next_page_url": %[4]s}
+			}`, page, to, from, next)
+		}))
+		defer server.Close()
+
+		got, err := c.ResendFailed(context.Background(), nil)
+		assert.NoError(err)
+		assert.Len(got, 2)
+		assert.Equal([]string{"https://www.example.com/0.pdf", "https://www.example.com/1.pdf"}, sent)
+	})
+
+	t.Run("Filtered", func(t *testing.T) {
+		var sent []string
+
+		// The server ignores the filters, returning faxes outside the window and to other numbers.
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				r.ParseForm()
+				sent = append(sent, r.PostForm.Get("MediaUrl"))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(sendResponseJSON))
+				return
+			}
+
+			fmt.Fprintf(w, `{"faxes": [
+				{"sid": "FX1", "to": "%[1]s", "from": "%[2]s", "status": "failed", "media_url": "https://www.example.com/1.pdf", "date_created": "2021-02-28T12:00:00Z"},
+				{"sid": "FX2", "to": "%[1]s", "from": "%[2]s", "status": "failed", "media_url": "https://www.example.com/2.pdf", "date_created": "2021-03-15T12:00:00Z"},
+				{"sid": "FX3", "to": "%[1]s", "from": "%[2]s", "status": "failed", "media_url": "https://www.example.com/3.pdf", "date_created": "2021-04-02T12:00:00Z"},
+				{"sid": "FX4", "to": "+15550000000", "from": "%[2]s", "status": "failed", "media_url": "https://www.example.com/4.pdf", "date_created": "2021-03-15T12:00:00Z"}
+			], "meta": {}}`, to, from)
+		}))
+		defer server.Close()
+
+		after := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+		got, err := c.ResendFailed(context.Background(), &ListOpts{
+			DateCreatedAfter:      after,
+			DateCreatedOnOrBefore: after.AddDate(0, 1, 0),
+			To:                    to,
+		})
+		assert.NoError(err)
+		assert.Len(got, 1)
+		assert.Equal([]string{"https://www.example.com/2.pdf"}, sent)
+	})
+}
+
+func TestClient_Concurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(sendResponseJSON))
+			return
+		}
+
+		w.Write([]byte(getResponseJSON))
+	}))
+	defer server.Close()
+
+	want := *c.SendOpts
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := c.Get(faxSID)
+			assert.NoError(err)
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			_, err := c.Send(to, from, faxMediaURL)
+			assert.NoError(err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(want, *c.SendOpts)
 }
@@ -0,0 +1,27 @@
+package fox
+
+import "time"
+
+// Clock is a source of the current time and of timed waits, used by the Client for polling
+// intervals and cache and dedupe expiry. Tests can supply a fake to control time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel on which the current time is sent once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// clock returns the Client's Clock, or the real clock if none is set.
+func (c *Client) clock() Clock {
+	if c.Clock == nil {
+		return realClock{}
+	}
+
+	return c.Clock
+}
@@ -0,0 +1,100 @@
+package fox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a Clock whose waits complete immediately, advancing its time by the duration waited.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func (fc *fakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	return fc.now
+}
+
+func (fc *fakeClock) After(d time.Duration) <-chan time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.now = fc.now.Add(d)
+	fc.waits = append(fc.waits, d)
+
+	ch := make(chan time.Time, 1)
+	ch <- fc.now
+	return ch
+}
+
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.now = fc.now.Add(d)
+}
+
+func TestClient_Clock(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("WaitForTerminalBackoff", func(t *testing.T) {
+		polls := 0
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			polls++
+
+			status := "sending"
+			if polls == 5 {
+				status = "delivered"
+			}
+
+			fmt.Fprintf(w, `{"sid": "%s", "status": "%s"}`, faxSID, status)
+		}))
+		defer server.Close()
+
+		fc := &fakeClock{now: time.Now()}
+		cc := c.Clone()
+		cc.Clock = fc
+
+		start := time.Now()
+		got, err := cc.WaitForTerminalBackoff(context.Background(), faxSID, time.Minute, 4*time.Minute)
+
+		assert.NoError(err)
+		assert.Equal("delivered", got.Status)
+		assert.Equal([]time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute}, fc.waits)
+		assert.True(time.Since(start) < time.Second)
+	})
+
+	t.Run("CacheTTL", func(t *testing.T) {
+		requests := 0
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			fmt.Fprintf(w, `{"sid": "%s", "status": "sending"}`, faxSID)
+		}))
+		defer server.Close()
+
+		fc := &fakeClock{now: time.Now()}
+		cc := c.Clone()
+		cc.Clock = fc
+		cc.CacheTTL = time.Minute
+
+		cc.Get(faxSID)
+		cc.Get(faxSID)
+		assert.Equal(1, requests)
+
+		fc.Advance(2 * time.Minute)
+		cc.Get(faxSID)
+		assert.Equal(2, requests)
+	})
+}
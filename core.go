@@ -1,7 +1,8 @@
 // Package fox implements a simple client for the Twilio programmatic fax API. It implements all
 // the functions associated with the "Faxes" endpoint, but to keep the library tight, does not
-// facilitate, for example, E.164 phone number parsing and validation, or handling Twilio status
-// callbacks.
+// facilitate, for example, E.164 phone number parsing and validation. Twilio status callbacks can
+// be validated and parsed with ValidateSignature and ParseStatusCallback, served by a handler from
+// NewCallbackHandler, or awaited after a send with Client.SendAwaitCallback.
 //
 // To get started, construct a new Client with your Twilio account SID and auth token:
 //
@@ -18,9 +19,15 @@
 package fox
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -30,7 +37,7 @@ var (
 )
 
 const (
-	version  = "v1" // pins this package to API v1
+	version  = "v1" // the default API version; see WithAPIVersion
 	endpoint = "Faxes"
 )
 
@@ -61,6 +68,68 @@ func (qt qualityType) String() string {
 	}
 }
 
+// QualityDisplayNames maps each quality to a name suitable for display to users, such as in a
+// quality picker, which includes its resolution.
+var QualityDisplayNames = map[qualityType]string{
+	QualityStandard:  "Standard (204x98)",
+	QualityFine:      "Fine (204x196)",
+	QualitySuperfine: "Superfine (204x392)",
+}
+
+// AllQualities returns every quality, from lowest to highest: QualityStandard, QualityFine and
+// QualitySuperfine.
+func AllQualities() []qualityType {
+	return []qualityType{QualityStandard, QualityFine, QualitySuperfine}
+}
+
+// ParseQuality returns the quality constant corresponding to s, one of "standard", "fine" or
+// "superfine". It returns ErrInvalidQuality for any other value.
+func ParseQuality(s string) (qualityType, error) {
+	switch s {
+	default:
+		return 0, ErrInvalidQuality
+	case "standard":
+		return QualityStandard, nil
+	case "fine":
+		return QualityFine, nil
+	case "superfine":
+		return QualitySuperfine, nil
+	}
+}
+
+type directionType int
+
+const (
+	// DirectionInbound indicates a fax received by the account.
+	DirectionInbound directionType = iota + 1 // the zero value is reserved for an unknown direction
+	// DirectionOutbound indicates a fax sent from the account.
+	DirectionOutbound
+)
+
+func (dt directionType) String() string {
+	switch dt {
+	default:
+		return ""
+	case DirectionInbound:
+		return "inbound"
+	case DirectionOutbound:
+		return "outbound"
+	}
+}
+
+// ParseDirection returns the direction constant corresponding to s, either "inbound" or
+// "outbound". It returns ErrInvalidDirection for any other value.
+func ParseDirection(s string) (directionType, error) {
+	switch s {
+	default:
+		return 0, ErrInvalidDirection
+	case "inbound":
+		return DirectionInbound, nil
+	case "outbound":
+		return DirectionOutbound, nil
+	}
+}
+
 type statusType int
 
 const (
@@ -116,7 +185,130 @@ func (st statusType) String() string {
 	}
 }
 
+// IsTerminal reports whether the status is final, meaning the fax's status won't change again:
+// StatusDelivered, StatusReceived, StatusFailed, StatusNoAnswer, StatusBusy or StatusCanceled.
+func (st statusType) IsTerminal() bool {
+	switch st {
+	case StatusDelivered, StatusReceived, StatusCanceled:
+		return true
+	}
+
+	return st.IsFailure()
+}
+
+// IsFailure reports whether the status is one in which a fax failed to send: StatusFailed,
+// StatusNoAnswer or StatusBusy.
+func (st statusType) IsFailure() bool {
+	switch st {
+	case StatusFailed, StatusNoAnswer, StatusBusy:
+		return true
+	}
+
+	return false
+}
+
+// AllStatuses returns every status, in the order they're defined, from StatusQueued to
+// StatusCanceled.
+func AllStatuses() []statusType {
+	statuses := make([]statusType, 0, StatusCanceled-StatusQueued+1)
+	for st := StatusQueued; st <= StatusCanceled; st++ {
+		statuses = append(statuses, st)
+	}
+
+	return statuses
+}
+
+// TerminalStatuses returns every status for which IsTerminal is true, in the order they're defined.
+func TerminalStatuses() []statusType {
+	return filterStatuses(func(st statusType) bool { return st.IsTerminal() })
+}
+
+// InProgressStatuses returns every status for which IsTerminal is false, those of faxes still being
+// queued, processed, sent or received, in the order they're defined.
+func InProgressStatuses() []statusType {
+	return filterStatuses(func(st statusType) bool { return !st.IsTerminal() })
+}
+
+// filterStatuses returns the statuses returned by AllStatuses for which keep returns true.
+func filterStatuses(keep func(statusType) bool) []statusType {
+	var statuses []statusType
+	for _, st := range AllStatuses() {
+		if keep(st) {
+			statuses = append(statuses, st)
+		}
+	}
+
+	return statuses
+}
+
+// statusUnknown is the statusType returned for an unrecognized status string. Its String method
+// returns an empty string, and it's neither terminal nor a failure.
+const statusUnknown statusType = -1
+
+// parseStatus returns the status constant whose string form is s, and whether there is one. If
+// there isn't, it returns statusUnknown.
+func parseStatus(s string) (statusType, bool) {
+	for st := StatusQueued; st <= StatusCanceled; st++ {
+		if st.String() == s {
+			return st, true
+		}
+	}
+
+	return statusUnknown, false
+}
+
+// isFailedStatus reports whether s is the string form of a status for which IsFailure is true.
+func isFailedStatus(s string) bool {
+	st, ok := parseStatus(s)
+	return ok && st.IsFailure()
+}
+
+const (
+	accountSIDPrefix = "AC"
+	apiKeySIDPrefix  = "SK"
+	faxSIDPrefix     = "FX"
+	mediaSIDPrefix   = "ME"
+	sidLength        = 34
+	secretLength     = 32 // the length of an auth token or API key secret
+)
+
+// validateSID returns ErrMissingSID if sid is empty, or ErrInvalidSID if it isn't a 34-character
+// string beginning with prefix.
+func validateSID(sid, prefix string) error {
+	if sid == "" {
+		return ErrMissingSID
+	}
+	if len(sid) != sidLength || !strings.HasPrefix(sid, prefix) {
+		return ErrInvalidSID
+	}
+
+	return nil
+}
+
+// SIDFromURL returns the fax SID embedded in the path of a fax resource URL, such as
+// SendResponse.URL or Links.Media: the last path segment that's a 34-character string beginning
+// with "FX". It returns ErrInvalidSID if there's no such segment.
+func SIDFromURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	segments := strings.Split(u.Path, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if validateSID(segments[i], faxSIDPrefix) == nil {
+			return segments[i], nil
+		}
+	}
+
+	return "", ErrInvalidSID
+}
+
 // ListOpts describes the options to use when listing faxes.
+//
+// Filters combine: every set field is sent as its own parameter, along with those in Extra, and
+// Twilio only returns faxes matching all of them. For instance, setting From, To and both dates
+// lists the faxes sent from From to To within that date range.
 type ListOpts struct {
 	// DateCreatedAfter filters the returned list to only include faxes created after the supplied
 	// date.
@@ -124,11 +316,21 @@ type ListOpts struct {
 	// DateCreatedOnOrBefore filters the returned list to only include faxes created on or before the
 	// supplied date.
 	DateCreatedOnOrBefore time.Time
+	// Extra holds additional list parameters not yet modeled by ListOpts, such as filters added to
+	// Twilio's API after this package was written. Entries for parameters ListOpts does model
+	// (DateCreatedAfter, DateCreatedOnOrBefore, From, Status and To) are ignored.
+	Extra url.Values
 	// From filters the returned list to only include faxes sent from the supplied number, given in
-	// E.164 format.
+	// E.164 format, or SIP address, such as "sip:kate@example.com", which is sent unaltered.
 	From string
+	// Limit, if non-zero, is the maximum number of faxes Client.ListAll collects before it stops
+	// fetching further pages. It isn't sent to Twilio, and doesn't affect Client.List.
+	Limit int
+	// Status, if non-nil, filters the returned list to only include faxes with the supplied status.
+	// It's a pointer because StatusQueued is the zero value of a status.
+	Status *statusType
 	// To filters the returned list to only include faxes sent to the supplied number, given in E.164
-	// format.
+	// format, or SIP address, which is sent unaltered.
 	To string
 }
 
@@ -143,13 +345,77 @@ func (lo *ListOpts) urlEncode(data url.Values) {
 	if lo.From != "" {
 		data.Add("From", lo.From)
 	}
+	if lo.Status != nil {
+		data.Add("Status", lo.Status.String())
+	}
 	if lo.To != "" {
 		data.Add("To", lo.To)
 	}
+
+	for k, vs := range lo.Extra {
+		if listOptsParams[k] {
+			continue
+		}
+		for _, v := range vs {
+			data.Add(k, v)
+		}
+	}
+}
+
+// listOptsParams is the set of list parameters modeled by ListOpts, which ListOpts.Extra can't
+// override.
+var listOptsParams = map[string]bool{
+	"DateCreatedAfter":      true,
+	"DateCreatedOnOrBefore": true,
+	"From":                  true,
+	"Status":                true,
+	"To":                    true,
+}
+
+// matches reports whether fax satisfies the filters of lo modeled by ListOpts, so that callers acting
+// on listed faxes don't rely on Twilio having applied them. A nil lo matches every fax.
+func (lo *ListOpts) matches(fax *SendResponse) bool {
+	if lo == nil {
+		return true
+	}
+
+	if !lo.DateCreatedAfter.IsZero() && !fax.DateCreated.After(lo.DateCreatedAfter) {
+		return false
+	}
+	if !lo.DateCreatedOnOrBefore.IsZero() && fax.DateCreated.After(lo.DateCreatedOnOrBefore) {
+		return false
+	}
+	if lo.From != "" && fax.From != lo.From {
+		return false
+	}
+	if lo.Status != nil && fax.Status != lo.Status.String() {
+		return false
+	}
+	if lo.To != "" && fax.To != lo.To {
+		return false
+	}
+
+	return true
 }
 
 // SendOpts describes the options to use when sending a fax.
+//
+// Twilio's fax API has no parameter governing retries of the fax itself: an unanswered fax simply
+// ends with the "no-answer" status, and is never resent automatically. To retry such faxes, use
+// Client.ResendFailed.
+//
+// Nor is there a parameter for the media's content type. Twilio determines it from the
+// Content-Type header served with the media, so to send a TIFF image, serve it as "image/tiff"
+// rather than, say, "application/octet-stream". PDF and TIFF are the supported types. There's no
+// compression setting either: Quality is the only tradeoff between transmission time and fidelity.
+//
+// Nor can the caller ID be set separately from the From number, which is what the recipient sees.
+// To present a different caller ID, send from a different number the account owns.
 type SendOpts struct {
+	// Extra holds additional send parameters not yet modeled by SendOpts, such as those added to
+	// Twilio's API after this package was written. Entries for the To, From and MediaUrl parameters,
+	// and for parameters SendOpts does model, are ignored.
+	Extra url.Values
 	// Quality is a quality value, one of QualityStandard, QualityFine or QualitySuperfine.
 	Quality qualityType
 	// SIPAuthPassword is the password to use for authentication when sending to a SIP address.
@@ -162,11 +428,48 @@ type SendOpts struct {
 	// StoreMedia specifies whether or not to store a copy of the sent media on Twilio's servers for
 	// later retrieval.
 	StoreMedia bool
+	// TTL is the duration from when a fax was initiated should Twilio attempt to send the fax. Twilio
+	// accepts whole minutes only, so TTL is rounded up to the next minute. If non-zero, it takes
+	// precedence over TTLMinutes.
+	TTL time.Duration
 	// TTLMinutes is the duration, in minutes, from when a fax was initiated should Twilio attempt to
 	// send the fax.
 	TTLMinutes int
 }
 
+// ttlMinutes returns the TTL in whole minutes, taken from TTL (rounded up) if it's non-zero and
+// from TTLMinutes otherwise.
+func (so *SendOpts) ttlMinutes() int {
+	if so.TTL != 0 {
+		return int((so.TTL + time.Minute - 1) / time.Minute)
+	}
+
+	return so.TTLMinutes
+}
+
+// Validate checks the SendOpts for self-consistency, returning ErrInvalidQuality,
+// ErrIncompleteSIPAuth, ErrInvalidTTL or ErrInvalidStatusCallback for the first problem found, or
+// nil if there's none.
+func (so *SendOpts) Validate() error {
+	if so.Quality.String() == "" {
+		return ErrInvalidQuality
+	}
+	if (so.SIPAuthUsername == "") != (so.SIPAuthPassword == "") {
+		return ErrIncompleteSIPAuth
+	}
+	if so.TTL < 0 || so.TTLMinutes < 0 {
+		return ErrInvalidTTL
+	}
+	if so.StatusCallback != "" {
+		u, err := url.Parse(so.StatusCallback)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInvalidStatusCallback
+		}
+	}
+
+	return nil
+}
+
 // urlEncode adds SendOpts fields to a url.Values map using standard param=value URL encoding.
 func (so *SendOpts) urlEncode(data url.Values) {
 	data.Add("Quality", so.Quality.String())
@@ -183,18 +486,75 @@ func (so *SendOpts) urlEncode(data url.Values) {
 
 	data.Add("StoreMedia", strconv.FormatBool(so.StoreMedia))
 
-	if so.TTLMinutes > 0 {
-		data.Add("Ttl", strconv.FormatInt(int64(so.TTLMinutes), 10))
+	if ttl := so.ttlMinutes(); ttl > 0 {
+		data.Add("Ttl", strconv.Itoa(ttl))
+	}
+
+	for k, vs := range so.Extra {
+		if sendParams[k] {
+			continue
+		}
+		for _, v := range vs {
+			data.Add(k, v)
+		}
 	}
 }
 
-// DefaultSendOpts is the default set of options to use for Client.Send. It mirrors the defaults
-// specified by Twilio.
-var DefaultSendOpts = &SendOpts{
+// Summary returns a human-readable summary of the parameters the SendOpts adds to a send, one
+// "Name: value" line per parameter in name order, for logging and debugging. The SIP auth password
+// is masked.
+func (so *SendOpts) Summary() string {
+	data := url.Values{}
+	so.urlEncode(data)
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		for _, v := range data[k] {
+			if k == "SipAuthPassword" {
+				v = "********"
+			}
+			fmt.Fprintf(&b, "%s: %s\n", k, v)
+		}
+	}
+
+	return b.String()
+}
+
+// sendParams is the set of send parameters given by the arguments to Client.Send or modeled by
+// SendOpts, which SendOpts.Extra can't override.
+var sendParams = map[string]bool{
+	"To":              true,
+	"From":            true,
+	"MediaUrl":        true,
+	"Quality":         true,
+	"SipAuthPassword": true,
+	"SipAuthUsername": true,
+	"StatusCallback":  true,
+	"StoreMedia":      true,
+	"Ttl":             true,
+}
+
+// defaultSendOpts is the default set of options to use for Client.Send. It mirrors the defaults
+// specified by Twilio. It is never handed out directly; see DefaultSendOpts.
+var defaultSendOpts = SendOpts{
 	Quality:    QualityFine,
 	StoreMedia: true,
 }
 
+// DefaultSendOpts returns a fresh copy of the default set of options to use for Client.Send, which
+// mirrors the defaults specified by Twilio. The copy can be modified freely without affecting other
+// clients.
+func DefaultSendOpts() *SendOpts {
+	so := defaultSendOpts
+	return &so
+}
+
 // ErrorResponse describes Twilio's error response.
 type ErrorResponse struct {
 	// Code is the unique Twilio error code.
@@ -212,6 +572,119 @@ func (err *ErrorResponse) Error() string {
 	return fmt.Sprintf("fox: error %v (Twilio error %v): %s", err.Status, err.Code, err.Message)
 }
 
+// DocsURL returns the link to the Twilio documentation for the error code, MoreInfo, which may be
+// empty if Twilio didn't supply one.
+func (err *ErrorResponse) DocsURL() string {
+	return err.MoreInfo
+}
+
+// ShortMessage returns the error's message without the "fox: " prefix that identifies the package,
+// for display to users.
+func (err *ErrorResponse) ShortMessage() string {
+	return strings.TrimPrefix(err.Error(), "fox: ")
+}
+
+// Is reports whether the error matches target, allowing errors.Is to identify the Twilio error codes
+// and HTTP statuses this package defines sentinels for, such as ErrMediaTooLarge and ErrNotFound.
+func (err *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrMediaTooLarge:
+		return err.Code == codeMediaTooLarge
+	case ErrBadRequest:
+		return err.IsBadRequest()
+	case ErrUnauthorized:
+		return err.IsUnauthorized()
+	case ErrNotFound:
+		return err.IsNotFound()
+	}
+
+	return false
+}
+
+// IsBadRequest reports whether Twilio rejected the request as malformed (400 BAD REQUEST).
+func (err *ErrorResponse) IsBadRequest() bool {
+	return err.Status == http.StatusBadRequest
+}
+
+// IsUnauthorized reports whether Twilio rejected the request's credentials (401 UNAUTHORIZED).
+func (err *ErrorResponse) IsUnauthorized() bool {
+	return err.Status == http.StatusUnauthorized
+}
+
+// IsNotFound reports whether the requested resource doesn't exist (404 NOT FOUND).
+func (err *ErrorResponse) IsNotFound() bool {
+	return err.Status == http.StatusNotFound
+}
+
+// codeMediaTooLarge is the Twilio error code reporting that fax media exceeds the maximum size.
+const codeMediaTooLarge = 15004
+
+// maxBodySnippet is the maximum number of bytes of a response body retained by an HTTPError.
+const maxBodySnippet = 256
+
+// HTTPError describes an error response whose body could not be decoded as an ErrorResponse, such
+// as the HTML or plain text page an intervening proxy returns for a 502 or 504, or JSON carrying
+// none of an ErrorResponse's fields.
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Body is a snippet of at most 256 bytes from the start of the response body.
+	Body string
+}
+
+// newHTTPError constructs an HTTPError from a status code and a response body, truncating the body.
+func newHTTPError(statusCode int, body []byte) *HTTPError {
+	if len(body) > maxBodySnippet {
+		body = body[:maxBodySnippet]
+	}
+
+	return &HTTPError{
+		StatusCode: statusCode,
+		Body:       strings.TrimSpace(string(body)),
+	}
+}
+
+// Error satisfies the error interface.
+func (err *HTTPError) Error() string {
+	return fmt.Sprintf("fox: error %v (%s): %s", err.StatusCode, http.StatusText(err.StatusCode), err.Body)
+}
+
+// Is reports whether the error matches target, allowing errors.Is to identify the HTTP statuses
+// this package defines sentinels for, ErrBadRequest, ErrUnauthorized and ErrNotFound, as it can for
+// an ErrorResponse.
+func (err *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return err.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return err.StatusCode == http.StatusUnauthorized
+	case ErrNotFound:
+		return err.StatusCode == http.StatusNotFound
+	}
+
+	return false
+}
+
+// ResendError describes the faxes that Client.ResendFailed was unable to resend, mapping each fax's
+// SID to the reason it couldn't be resent.
+type ResendError map[string]error
+
+// Error satisfies the error interface.
+func (err ResendError) Error() string {
+	sids := make([]string, 0, len(err))
+	for sid := range err {
+		sids = append(sids, sid)
+	}
+	sort.Strings(sids)
+
+	msgs := make([]string, len(sids))
+	for i, sid := range sids {
+		msgs[i] = fmt.Sprintf("%s: %v", sid, err[sid])
+	}
+
+	return fmt.Sprintf("fox: %d fax(es) could not be resent (%s)", len(err), strings.Join(msgs, "; "))
+}
+
 // Meta describes the metadata object component of a ListResponse
 type Meta struct {
 	FirstPageURL    string `json:"first_page_url"`
@@ -223,12 +696,106 @@ type Meta struct {
 	URL             string `json:"url"`
 }
 
-// ListResponse describes the success response returned from listing faxes.
+// UnmarshalJSON satisfies the json.Unmarshaler interface. Twilio doesn't always encode page and
+// page_size consistently across resources, so each is accepted as either a number or a string
+// holding one.
+func (m *Meta) UnmarshalJSON(b []byte) error {
+	type meta Meta // avoids recursing into UnmarshalJSON
+	aux := struct {
+		*meta
+		Page     flexInt `json:"page"`
+		PageSize flexInt `json:"page_size"`
+	}{meta: (*meta)(m)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	m.Page = int(aux.Page)
+	m.PageSize = int(aux.PageSize)
+	return nil
+}
+
+// flexInt is an int that can be decoded from either a JSON number or a string holding one.
+type flexInt int
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.
+func (fi *flexInt) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("fox: invalid integer %s", b)
+	}
+
+	*fi = flexInt(n)
+	return nil
+}
+
+// ListResponse describes the success response returned from listing faxes. A ListResponse returned
+// by a Client always has a non-nil Faxes, which is empty when there are no results, so a nil Faxes
+// means the list wasn't fetched.
 type ListResponse struct {
 	Faxes []SendResponse `json:"faxes"`
 	Meta  Meta           `json:"meta"`
 }
 
+// IsEmpty reports whether the list contains no faxes.
+func (lr *ListResponse) IsEmpty() bool {
+	return len(lr.Faxes) == 0
+}
+
+// HasNext reports whether there's a page of faxes following this one.
+func (lr *ListResponse) HasNext() bool {
+	return lr.Meta.NextPageURL != ""
+}
+
+// CountByStatus returns the number of faxes in the list with each status, keyed by the status's
+// string form, such as "delivered".
+func (lr *ListResponse) CountByStatus() map[string]int {
+	counts := map[string]int{}
+	for _, fax := range lr.Faxes {
+		counts[fax.Status]++
+	}
+
+	return counts
+}
+
+// TotalPrice returns the sum of the prices of the faxes in the list, along with their currency
+// unit. Faxes that haven't been priced yet are skipped; if none have, the total is 0 and the unit
+// is empty. It returns ErrMixedCurrencies if the faxes were priced in more than one currency.
+func (lr *ListResponse) TotalPrice() (float64, string, error) {
+	var total float64
+	var unit string
+
+	for _, fax := range lr.Faxes {
+		if fax.Price == nil {
+			continue
+		}
+
+		price, err := strconv.ParseFloat(*fax.Price, 64)
+		if err != nil {
+			return 0, "", err
+		}
+
+		if unit == "" {
+			unit = fax.PriceUnit
+		} else if fax.PriceUnit != unit {
+			return 0, "", ErrMixedCurrencies
+		}
+
+		total += price
+	}
+
+	return total, unit, nil
+}
+
 // SendResponse describes the success response returned from sending a fax.
 type SendResponse struct {
 	// AccountSid	is the unique SID identifier of the account from which the fax was sent.
@@ -241,7 +808,7 @@ type SendResponse struct {
 	SID string `json:"sid"`
 	// URL is the fully-qualified reference URL to the fax resource.
 	URL string `json:"url"`
-	// Direction is the transmission direction of this fax. Always "outbound".
+	// Direction is the transmission direction of this fax, either "inbound" or "outbound".
 	Direction string `json:"direction"`
 	// To	is the phone number or SIP URI of the destination.
 	To string `json:"to"`
@@ -249,25 +816,282 @@ type SendResponse struct {
 	From string `json:"from"`
 	// Quality is one of "standard", "fine" or "superfine".
 	Quality string `json:"quality"`
+	// ParsedQuality is Quality parsed into one of QualityStandard, QualityFine or QualitySuperfine.
+	// It is only meaningful when Quality is non-empty.
+	ParsedQuality qualityType `json:"-"`
 	// DateCreated is the timestamp at which the fax resource was created.
 	DateCreated time.Time `json:"date_created"`
 	// DateUpdated is the timestamp at which the fax resource was updated.
 	DateUpdated time.Time `json:"date_updated"`
+	// RemoteStationID is the called subscriber identification (CSID) reported by the receiving fax
+	// machine. It's empty until the fax has been delivered.
+	RemoteStationID string `json:"remote_station_id"`
 	// Links is a dictionary of URL links to nested resources of this fax.
-	Links struct {
-		// Media is a fully-qualified reference URL to the fax media resource.
-		Media string `json:"media"`
-	} `json:"links"`
-	// MediaSid string `json:"media_sid"`
+	Links Links `json:"links"`
+	// MediaSID is the 34-character string that uniquely identifies the fax media.
+	MediaSID string `json:"media_sid"`
 	// PriceUnit is the currency unit of the Price. E.g., "USD".
 	PriceUnit string `json:"price_unit"`
-	Price     string `json:"price"`
-	// Duration is the time taken to transmit the fax, in seconds.
-	Duration int    `json:"duration"`
-	NumPages int    `json:"num_pages"`
+	// Price is the cost of the fax, or nil if it hasn't been determined yet (as for a newly created,
+	// queued fax).
+	Price *string `json:"price"`
+	// Duration is the time taken to transmit the fax, in seconds, or nil if it hasn't been
+	// transmitted yet.
+	Duration *int `json:"duration"`
+	// NumPages is the number of pages in the fax, or nil if it hasn't been processed yet.
+	NumPages *int   `json:"num_pages"`
 	MediaURL string `json:"media_url"`
 }
 
+// UnmarshalJSON satisfies the json.Unmarshaler interface, populating ParsedQuality from Quality.
+// An unrecognized quality leaves ParsedQuality unset rather than failing the decode.
+func (sr *SendResponse) UnmarshalJSON(b []byte) error {
+	type sendResponse SendResponse // avoids recursing into UnmarshalJSON
+	if err := json.Unmarshal(b, (*sendResponse)(sr)); err != nil {
+		return err
+	}
+
+	if q, err := ParseQuality(sr.Quality); err == nil {
+		sr.ParsedQuality = q
+	}
+
+	return nil
+}
+
+// Links describes the URL links to the nested resources of a fax.
+type Links struct {
+	// Media is a fully-qualified reference URL to the fax media resource.
+	Media string `json:"media"`
+	// Other holds any other links Twilio returns, keyed by name, such as those added to its API
+	// after this package was written, so that they aren't dropped. It's nil if there are none.
+	Other map[string]string `json:"-"`
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface, collecting links other than Media into
+// Other. Null links are decoded as empty strings.
+func (l *Links) UnmarshalJSON(b []byte) error {
+	// Twilio typically links only the media, so decode that directly when it's alone, sparing a map
+	// for every fax in a list.
+	if isMediaLinkOnly(b) {
+		*l = Links{}
+		return json.Unmarshal(b, (*plainLinks)(l))
+	}
+
+	var links map[string]*string
+	if err := json.Unmarshal(b, &links); err != nil {
+		return err
+	}
+
+	*l = Links{}
+	for name, link := range links {
+		var v string
+		if link != nil {
+			v = *link
+		}
+
+		if name == "media" {
+			l.Media = v
+			continue
+		}
+		if l.Other == nil {
+			l.Other = make(map[string]string)
+		}
+		l.Other[name] = v
+	}
+
+	return nil
+}
+
+// plainLinks is Links without its methods, so that its Media field can be decoded by encoding/json.
+type plainLinks Links
+
+// isMediaLinkOnly reports whether b is a JSON object whose only key is "media". Commas within strings
+// and nested values are skipped, so that only one separating top-level keys counts.
+func isMediaLinkOnly(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || b[0] != '{' {
+		return false
+	}
+
+	rest := bytes.TrimLeft(b[1:], " \t\r\n")
+	if !bytes.HasPrefix(rest, []byte(`"media"`)) {
+		return false
+	}
+
+	var depth int
+	var inString, escaped bool
+	for _, ch := range rest {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch ch {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// MarshalJSON satisfies the json.Marshaler interface, encoding Other alongside Media.
+func (l Links) MarshalJSON() ([]byte, error) {
+	links := make(map[string]string, len(l.Other)+1)
+	for name, link := range l.Other {
+		links[name] = link
+	}
+	links["media"] = l.Media
+
+	return json.Marshal(links)
+}
+
+// ParsedDirection returns Direction parsed into either DirectionInbound or DirectionOutbound, or
+// the zero directionType if Direction is empty or unrecognized.
+func (sr *SendResponse) ParsedDirection() directionType {
+	dt, _ := ParseDirection(sr.Direction)
+	return dt
+}
+
+// EstimateCost returns the projected cost of sending a fax of the given number of pages at the
+// given price per page.
+func EstimateCost(pages int, pricePerPage float64) float64 {
+	return float64(pages) * pricePerPage
+}
+
+// EqualIgnoring reports whether sr and other are equal in every field except those named, given by
+// their Go field names, such as "SID" or "DateUpdated". It's intended for tests that compare faxes
+// whose identifiers and timestamps vary between runs. Names that aren't fields are ignored.
+func (sr *SendResponse) EqualIgnoring(other *SendResponse, fields ...string) bool {
+	if sr == nil || other == nil {
+		return sr == other
+	}
+
+	ignored := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		ignored[f] = true
+	}
+
+	a, b := reflect.ValueOf(sr).Elem(), reflect.ValueOf(other).Elem()
+	for i := 0; i < a.NumField(); i++ {
+		if ignored[a.Type().Field(i).Name] {
+			continue
+		}
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MediaStored reports whether Twilio holds a copy of the fax's media, and so whether it can be
+// downloaded. It's based on MediaURL, which is null when the media wasn't stored or has been
+// deleted; Links.Media is present regardless, so isn't an indication.
+func (sr *SendResponse) MediaStored() bool {
+	return sr.MediaURL != ""
+}
+
+// NormalizeNumbers rewrites To and From in the E.164 format, stripping formatting characters such
+// as spaces, dashes and parentheses. Values that don't look like phone numbers, such as SIP
+// addresses and SIP From display names, are left untouched.
+func (sr *SendResponse) NormalizeNumbers() {
+	sr.To = normalizeNumber(sr.To)
+	sr.From = normalizeNumber(sr.From)
+}
+
+// normalizeNumber returns the phone number s with everything but its leading plus sign and digits
+// removed. If s doesn't look like a phone number, consisting only of digits and the formatting
+// characters space, dash, dot and parentheses after an optional leading plus sign, it's returned
+// unaltered.
+func normalizeNumber(s string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(s) {
+		switch {
+		case r >= '0' && r <= '9', r == '+' && i == 0:
+			b.WriteRune(r)
+		case strings.ContainsRune(" -.()", r):
+		default:
+			return s
+		}
+	}
+
+	if strings.TrimPrefix(b.String(), "+") == "" {
+		return s
+	}
+
+	return b.String()
+}
+
+// TransmissionTime returns the time taken to transmit the fax, or 0 if Duration is nil.
+func (sr *SendResponse) TransmissionTime() time.Duration {
+	if sr.Duration == nil {
+		return 0
+	}
+
+	return time.Duration(*sr.Duration) * time.Second
+}
+
+// EstimateCost returns the projected cost of the fax at the given price per page, based on its
+// NumPages, before Twilio has priced it. It returns ErrUnknownPageCount if NumPages is nil.
+func (sr *SendResponse) EstimateCost(pricePerPage float64) (float64, error) {
+	if sr.NumPages == nil {
+		return 0, ErrUnknownPageCount
+	}
+
+	return EstimateCost(*sr.NumPages, pricePerPage), nil
+}
+
+// clone returns a deep copy of sr, sharing none of its pointers or maps, so that a copy handed to a
+// caller can't alter the original or vice versa.
+func (sr *SendResponse) clone() *SendResponse {
+	clone := *sr
+
+	if sr.Price != nil {
+		price := *sr.Price
+		clone.Price = &price
+	}
+	if sr.Duration != nil {
+		duration := *sr.Duration
+		clone.Duration = &duration
+	}
+	if sr.NumPages != nil {
+		numPages := *sr.NumPages
+		clone.NumPages = &numPages
+	}
+	if sr.Links.Other != nil {
+		clone.Links.Other = make(map[string]string, len(sr.Links.Other))
+		for name, link := range sr.Links.Other {
+			clone.Links.Other[name] = link
+		}
+	}
+
+	return &clone
+}
+
+// MediaPart describes a single part of a fax's media, as returned by Client.DownloadMediaParts.
+type MediaPart struct {
+	// ContentType is the MIME type of the part, e.g. "application/pdf".
+	ContentType string
+	// Data is the content of the part.
+	Data []byte
+}
+
 // StatusCallbackResponse describes the response received from calling a status callback.
 type StatusCallbackResponse struct {
 	// FaxSid is the 34-character unique identifier for the fax.
@@ -297,4 +1121,58 @@ type StatusCallbackResponse struct {
 	ErrorCode int
 	// ErrorMessage is a detailed message describing a failure, if any.
 	ErrorMessage string
+	// Quality is the quality the fax was sent at, one of "standard", "fine" or "superfine", if the
+	// callback reports it. Twilio doesn't document it as a callback parameter, so it's often empty.
+	Quality string
+}
+
+// FaxStatusType returns FaxStatus parsed into one of the status constants, such as StatusDelivered
+// or StatusNoAnswer. If FaxStatus is unrecognized, the returned statusType's String method returns
+// an empty string.
+func (cb *StatusCallbackResponse) FaxStatusType() statusType {
+	st, _ := parseStatus(cb.FaxStatus)
+	return st
+}
+
+// ToSendResponse returns a SendResponse populated from the fields the callback shares with it:
+// SID, AccountSid, APIVersion, Status, To, From, RemoteStationID, NumPages, MediaURL and Quality. A
+// callback carries no equivalent of the other fields (such as Direction, Price, Duration, Links and
+// the creation and update dates), so they're left empty; use Client.Get to retrieve them. NumPages
+// is nil unless the callback reports a page count.
+func (cb *StatusCallbackResponse) ToSendResponse() *SendResponse {
+	sr := SendResponse{
+		AccountSid:      cb.AccountSid,
+		APIVersion:      cb.APIVersion,
+		Status:          cb.FaxStatus,
+		SID:             cb.FaxSid,
+		To:              cb.To,
+		From:            cb.From,
+		RemoteStationID: cb.RemoteStationID,
+		MediaURL:        cb.MediaURL,
+		Quality:         cb.Quality,
+	}
+
+	if q, err := ParseQuality(cb.Quality); err == nil {
+		sr.ParsedQuality = q
+	}
+	if cb.NumPages > 0 {
+		numPages := cb.NumPages
+		sr.NumPages = &numPages
+	}
+
+	return &sr
+}
+
+// ToSendOpts returns a best-effort reconstruction of the options the fax was sent with, for
+// resending it after a failure. Only the quality is recoverable, and only if the callback reports a
+// valid one; callbacks carry none of the other options, so they're left at their defaults (see
+// DefaultSendOpts). The to and from numbers and media URL to resend with are To, From and
+// OriginalMediaURL.
+func (cb *StatusCallbackResponse) ToSendOpts() *SendOpts {
+	so := DefaultSendOpts()
+	if q, err := ParseQuality(cb.Quality); err == nil {
+		so.Quality = q
+	}
+
+	return so
 }
@@ -1,6 +1,8 @@
 package fox
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"testing"
@@ -21,6 +23,337 @@ func TestErrorResponse_Error(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestErrorResponse_DocsURL(t *testing.T) {
+	in := ErrorResponse{Code: 20404, MoreInfo: "https://www.twilio.com/docs/errors/20404"}
+	assert.Equal(t, "https://www.twilio.com/docs/errors/20404", in.DocsURL())
+}
+
+func TestErrorResponse_ShortMessage(t *testing.T) {
+	in := ErrorResponse{
+		Code:    12228,
+		Message: "Twilio error message",
+		Status:  404,
+	}
+
+	assert.Equal(t, "error 404 (Twilio error 12228): Twilio error message", in.ShortMessage())
+}
+
+func TestErrorResponse_Is(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(&ErrorResponse{Code: codeMediaTooLarge}, ErrMediaTooLarge))
+	assert.True(errors.Is(fmt.Errorf("sending: %w", &ErrorResponse{Code: codeMediaTooLarge}), ErrMediaTooLarge))
+	assert.False(errors.Is(&ErrorResponse{Code: 1228}, ErrMediaTooLarge))
+	assert.False(errors.Is(&ErrorResponse{Code: codeMediaTooLarge}, ErrMissingSID))
+}
+
+func TestErrorResponse_classification(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		status                             int
+		badRequest, unauthorized, notFound bool
+	}{
+		{400, true, false, false},
+		{401, false, true, false},
+		{404, false, false, true},
+		{500, false, false, false},
+	}
+
+	for _, tc := range tests {
+		err := &ErrorResponse{Status: tc.status}
+
+		assert.Equal(tc.badRequest, err.IsBadRequest(), tc.status)
+		assert.Equal(tc.unauthorized, err.IsUnauthorized(), tc.status)
+		assert.Equal(tc.notFound, err.IsNotFound(), tc.status)
+		assert.Equal(tc.badRequest, errors.Is(err, ErrBadRequest), tc.status)
+		assert.Equal(tc.unauthorized, errors.Is(err, ErrUnauthorized), tc.status)
+		assert.Equal(tc.notFound, errors.Is(err, ErrNotFound), tc.status)
+	}
+}
+
+func TestHTTPError_Is(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(&HTTPError{StatusCode: 400}, ErrBadRequest))
+	assert.True(errors.Is(&HTTPError{StatusCode: 401}, ErrUnauthorized))
+	assert.True(errors.Is(&HTTPError{StatusCode: 404}, ErrNotFound))
+	assert.False(errors.Is(&HTTPError{StatusCode: 502}, ErrNotFound))
+	assert.False(errors.Is(&HTTPError{StatusCode: 404}, ErrMediaTooLarge))
+}
+
+func TestHTTPError_Error(t *testing.T) {
+	in := newHTTPError(504, []byte("  Gateway Timeout\n"))
+
+	want := "fox: error 504 (Gateway Timeout): Gateway Timeout"
+	got := in.Error()
+	assert.Equal(t, want, got)
+}
+
+func TestResendError_Error(t *testing.T) {
+	in := ResendError{
+		"FXB": ErrMissingMediaURL,
+		"FXA": ErrMissingToNumber,
+	}
+
+	want := "fox: 2 fax(es) could not be resent (FXA: fox: to number is required; FXB: fox: media URL is required)"
+	got := in.Error()
+	assert.Equal(t, want, got)
+}
+
+func TestParseQuality(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, want := range []qualityType{QualityStandard, QualityFine, QualitySuperfine} {
+		t.Run(want.String(), func(t *testing.T) {
+			got, err := ParseQuality(want.String())
+			assert.NoError(err)
+			assert.Equal(want, got)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseQuality("ultrafine")
+		assert.Equal(ErrInvalidQuality, err)
+	})
+}
+
+func TestAllQualities(t *testing.T) {
+	assert := assert.New(t)
+
+	got := AllQualities()
+
+	assert.Equal([]qualityType{QualityStandard, QualityFine, QualitySuperfine}, got)
+	assert.Len(QualityDisplayNames, len(got))
+	for _, qt := range got {
+		assert.Contains(QualityDisplayNames, qt, qt.String())
+	}
+}
+
+func TestParseDirection(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, want := range []directionType{DirectionInbound, DirectionOutbound} {
+		t.Run(want.String(), func(t *testing.T) {
+			got, err := ParseDirection(want.String())
+			assert.NoError(err)
+			assert.Equal(want, got)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, in := range []string{"", "sideways"} {
+			got, err := ParseDirection(in)
+			assert.Equal(ErrInvalidDirection, err)
+			assert.Equal(directionType(0), got)
+		}
+
+		assert.Equal("", directionType(0).String())
+	})
+}
+
+func TestSendResponse_ParsedDirection(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(DirectionInbound, (&SendResponse{Direction: "inbound"}).ParsedDirection())
+	assert.Equal(DirectionOutbound, (&SendResponse{Direction: "outbound"}).ParsedDirection())
+	assert.Equal(directionType(0), (&SendResponse{}).ParsedDirection())
+}
+
+func TestSendResponse_UnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Quality", func(t *testing.T) {
+		var got SendResponse
+		assert.NoError(json.Unmarshal([]byte(`{"sid":"FX1","quality":"superfine"}`), &got))
+		assert.Equal("FX1", got.SID)
+		assert.Equal("superfine", got.Quality)
+		assert.Equal(QualitySuperfine, got.ParsedQuality)
+	})
+
+	t.Run("RemoteStationID", func(t *testing.T) {
+		var got SendResponse
+		assert.NoError(json.Unmarshal([]byte(`{"status":"delivered","remote_station_id":"+15558675310"}`), &got))
+		assert.Equal("+15558675310", got.RemoteStationID)
+	})
+
+	t.Run("Delivered", func(t *testing.T) {
+		var got SendResponse
+		assert.NoError(json.Unmarshal([]byte(`{"status":"delivered","num_pages":3,"price":"-0.021","duration":42}`), &got))
+
+		if assert.NotNil(got.NumPages) && assert.NotNil(got.Price) && assert.NotNil(got.Duration) {
+			assert.Equal(3, *got.NumPages)
+			assert.Equal("-0.021", *got.Price)
+			assert.Equal(42, *got.Duration)
+		}
+	})
+
+	t.Run("NoRemoteStationID", func(t *testing.T) {
+		var got SendResponse
+		assert.NoError(json.Unmarshal([]byte(`{"status":"queued","remote_station_id":null}`), &got))
+		assert.Equal("", got.RemoteStationID)
+
+		assert.NoError(json.Unmarshal([]byte(`{"status":"queued"}`), &got))
+		assert.Equal("", got.RemoteStationID)
+	})
+
+	t.Run("NullQuality", func(t *testing.T) {
+		var got SendResponse
+		assert.NoError(json.Unmarshal([]byte(`{"sid":"FX1","quality":null}`), &got))
+		assert.Equal("", got.Quality)
+		assert.Equal(qualityType(0), got.ParsedQuality)
+	})
+}
+
+func TestLinks_UnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	const mediaLink = "https://fax.twilio.com/v1/Faxes/FX1/Media"
+	const instanceLink = "https://fax.twilio.com/v1/Faxes/FX1/Media/ME1"
+
+	t.Run("Extra", func(t *testing.T) {
+		var got SendResponse
+		in := `{"sid":"FX1","links":{"media":"` + mediaLink + `","media_instance":"` + instanceLink + `"}}`
+		assert.NoError(json.Unmarshal([]byte(in), &got))
+
+		assert.Equal(mediaLink, got.Links.Media)
+		assert.Equal(map[string]string{"media_instance": instanceLink}, got.Links.Other)
+	})
+
+	t.Run("MediaOnly", func(t *testing.T) {
+		var got Links
+		assert.NoError(json.Unmarshal([]byte(`{"media":"`+mediaLink+`"}`), &got))
+		assert.Equal(Links{Media: mediaLink}, got)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		var got Links
+		assert.NoError(json.Unmarshal([]byte(`{"media":null}`), &got))
+		assert.Equal(Links{}, got)
+	})
+
+	t.Run("MediaFirst", func(t *testing.T) {
+		var got Links
+		in := `{ "media": "` + mediaLink + `?a=1,2\",", "media_instance": "` + instanceLink + `"}`
+		assert.NoError(json.Unmarshal([]byte(in), &got))
+
+		assert.Equal(mediaLink+`?a=1,2",`, got.Media)
+		assert.Equal(map[string]string{"media_instance": instanceLink}, got.Other)
+	})
+
+	t.Run("OtherOnly", func(t *testing.T) {
+		var got Links
+		assert.NoError(json.Unmarshal([]byte(`{"MEDIA":"`+mediaLink+`"}`), &got))
+		assert.Equal(Links{Other: map[string]string{"MEDIA": mediaLink}}, got)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var got Links
+		assert.Error(got.UnmarshalJSON([]byte(`{"media":1}`)))
+		assert.Error(got.UnmarshalJSON([]byte(`{"media":"`)))
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		in := Links{Media: mediaLink, Other: map[string]string{"media_instance": instanceLink}}
+
+		b, err := json.Marshal(in)
+		assert.NoError(err)
+
+		var got Links
+		assert.NoError(json.Unmarshal(b, &got))
+		assert.Equal(in, got)
+	})
+}
+
+func TestStatusType_IsTerminal(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		in       statusType
+		terminal bool
+		failure  bool
+	}{
+		{StatusQueued, false, false},
+		{StatusProcessing, false, false},
+		{StatusSending, false, false},
+		{StatusDelivered, true, false},
+		{StatusReceiving, false, false},
+		{StatusReceived, true, false},
+		{StatusNoAnswer, true, true},
+		{StatusBusy, true, true},
+		{StatusFailed, true, true},
+		{StatusCanceled, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in.String(), func(t *testing.T) {
+			assert.Equal(tt.terminal, tt.in.IsTerminal())
+			assert.Equal(tt.failure, tt.in.IsFailure())
+			assert.Equal(tt.failure, isFailedStatus(tt.in.String()))
+		})
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		assert.False(statusType(42).IsTerminal())
+		assert.False(statusType(42).IsFailure())
+		assert.False(isFailedStatus("unknown"))
+	})
+}
+
+func TestAllStatuses(t *testing.T) {
+	assert := assert.New(t)
+
+	got := AllStatuses()
+
+	assert.Equal([]statusType{
+		StatusQueued, StatusProcessing, StatusSending, StatusDelivered, StatusReceiving,
+		StatusReceived, StatusNoAnswer, StatusBusy, StatusFailed, StatusCanceled,
+	}, got)
+
+	seen := map[string]bool{}
+	for _, st := range got {
+		assert.NotEqual("", st.String())
+		assert.False(seen[st.String()], st.String())
+		seen[st.String()] = true
+	}
+
+	t.Run("Buckets", func(t *testing.T) {
+		terminal, inProgress := TerminalStatuses(), InProgressStatuses()
+
+		assert.Equal([]statusType{
+			StatusDelivered, StatusReceived, StatusNoAnswer, StatusBusy, StatusFailed, StatusCanceled,
+		}, terminal)
+		assert.Equal([]statusType{StatusQueued, StatusProcessing, StatusSending, StatusReceiving}, inProgress)
+		assert.Len(got, len(terminal)+len(inProgress))
+	})
+}
+
+func TestSIDFromURL(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, in := range []string{
+		"https://fax.twilio.com/v1/Faxes/" + faxSID,
+		"https://fax.twilio.com/v1/Faxes/" + faxSID + "/Media",
+		"https://fax.twilio.com/v1/Faxes/" + faxSID + "/Media/MEXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX?x=1",
+	} {
+		got, err := SIDFromURL(in)
+		assert.NoError(err, in)
+		assert.Equal(faxSID, got, in)
+	}
+
+	for _, in := range []string{
+		"https://fax.twilio.com/v1/Faxes",
+		"https://fax.twilio.com/v1/Faxes/FX123",
+		"https://fax.twilio.com/v1/Faxes/MEXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	} {
+		_, err := SIDFromURL(in)
+		assert.Equal(ErrInvalidSID, err, in)
+	}
+
+	_, err := SIDFromURL("://")
+	assert.Error(err)
+}
+
 func TestListOpts_urlEncode(t *testing.T) {
 	in := ListOpts{
 		DateCreatedAfter:      time.Now().Add(time.Hour * 4),
@@ -44,6 +377,96 @@ func TestListOpts_urlEncode(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestListOpts_urlEncodeStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Set", func(t *testing.T) {
+		status := StatusFailed
+		in := ListOpts{Status: &status}
+
+		data := url.Values{}
+		in.urlEncode(data)
+
+		assert.Equal("Status=failed", data.Encode())
+	})
+
+	t.Run("Queued", func(t *testing.T) {
+		status := StatusQueued
+		in := ListOpts{Status: &status}
+
+		data := url.Values{}
+		in.urlEncode(data)
+
+		assert.Equal("Status=queued", data.Encode())
+	})
+
+	t.Run("Unset", func(t *testing.T) {
+		in := ListOpts{}
+
+		data := url.Values{}
+		in.urlEncode(data)
+
+		assert.Equal("", data.Encode())
+	})
+}
+
+func TestListOpts_urlEncodeSIP(t *testing.T) {
+	assert := assert.New(t)
+
+	const sipTo = "sip:kate@example.com?hatchkey=4815162342;transport=TCP"
+
+	in := ListOpts{From: from, To: sipTo}
+
+	data := url.Values{}
+	in.urlEncode(data)
+
+	got, err := url.ParseQuery(data.Encode())
+	assert.NoError(err)
+	assert.Equal(sipTo, got.Get("To"))
+	assert.Equal(from, got.Get("From"))
+}
+
+func TestListOpts_urlEncodeExtra(t *testing.T) {
+	assert := assert.New(t)
+
+	in := ListOpts{
+		To: to,
+		Extra: url.Values{
+			"PageSize": {"20"},
+			"To":       {"+15550000000"},
+		},
+	}
+
+	data := url.Values{}
+	in.urlEncode(data)
+
+	assert.Equal("20", data.Get("PageSize"))
+	assert.Equal([]string{to}, data["To"])
+}
+
+func TestListOpts_matches(t *testing.T) {
+	assert := assert.New(t)
+
+	created := time.Date(2021, time.March, 15, 0, 0, 0, 0, time.UTC)
+	fax := SendResponse{DateCreated: created, From: from, Status: "failed", To: to}
+	failed := StatusFailed
+	delivered := StatusDelivered
+
+	var nilOpts *ListOpts
+	assert.True(nilOpts.matches(&fax))
+	assert.True((&ListOpts{}).matches(&fax))
+
+	assert.True((&ListOpts{DateCreatedAfter: created.Add(-time.Hour)}).matches(&fax))
+	assert.False((&ListOpts{DateCreatedAfter: created}).matches(&fax))
+	assert.True((&ListOpts{DateCreatedOnOrBefore: created}).matches(&fax))
+	assert.False((&ListOpts{DateCreatedOnOrBefore: created.Add(-time.Hour)}).matches(&fax))
+
+	assert.True((&ListOpts{From: from, To: to, Status: &failed}).matches(&fax))
+	assert.False((&ListOpts{From: to}).matches(&fax))
+	assert.False((&ListOpts{To: from}).matches(&fax))
+	assert.False((&ListOpts{Status: &delivered}).matches(&fax))
+}
+
 func TestSendOpts_urlEncode(t *testing.T) {
 	in := SendOpts{
 		Quality:         QualitySuperfine,
@@ -70,3 +493,444 @@ func TestSendOpts_urlEncode(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func TestSendOpts_Summary(t *testing.T) {
+	assert := assert.New(t)
+
+	in := SendOpts{
+		Quality:         QualitySuperfine,
+		SIPAuthPassword: "hunter2",
+		SIPAuthUsername: "username",
+		StoreMedia:      true,
+	}
+
+	want := "Quality: superfine\n" +
+		"SipAuthPassword: ********\n" +
+		"SipAuthUsername: username\n" +
+		"StoreMedia: true\n"
+
+	got := in.Summary()
+	assert.Equal(want, got)
+	assert.NotContains(got, "hunter2")
+}
+
+func TestSendOpts_urlEncodeExtra(t *testing.T) {
+	assert := assert.New(t)
+
+	in := SendOpts{
+		Quality: QualityFine,
+		Extra: url.Values{
+			"NewParam": {"value"},
+			"MediaUrl": {"https://www.example.com/other.pdf"},
+			"Quality":  {"superfine"},
+		},
+	}
+
+	data := url.Values{}
+	in.urlEncode(data)
+
+	assert.Equal("value", data.Get("NewParam"))
+	assert.Equal([]string{"fine"}, data["Quality"])
+	assert.NotContains(data, "MediaUrl")
+}
+
+func TestSendOpts_Validate(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Valid", func(t *testing.T) {
+		in := SendOpts{
+			Quality:         QualitySuperfine,
+			SIPAuthPassword: "password",
+			SIPAuthUsername: "username",
+			StatusCallback:  "https://example.com/callback",
+			TTLMinutes:      10,
+		}
+		assert.NoError(in.Validate())
+		assert.NoError(DefaultSendOpts().Validate())
+	})
+
+	t.Run("ErrInvalidQuality", func(t *testing.T) {
+		in := SendOpts{Quality: qualityType(42)}
+		assert.Equal(ErrInvalidQuality, in.Validate())
+	})
+
+	t.Run("ErrIncompleteSIPAuth", func(t *testing.T) {
+		in := SendOpts{SIPAuthPassword: "password"}
+		assert.Equal(ErrIncompleteSIPAuth, in.Validate())
+
+		in = SendOpts{SIPAuthUsername: "username"}
+		assert.Equal(ErrIncompleteSIPAuth, in.Validate())
+	})
+
+	t.Run("ErrInvalidTTL", func(t *testing.T) {
+		in := SendOpts{TTLMinutes: -1}
+		assert.Equal(ErrInvalidTTL, in.Validate())
+
+		in = SendOpts{TTL: -time.Minute}
+		assert.Equal(ErrInvalidTTL, in.Validate())
+	})
+
+	t.Run("ErrInvalidStatusCallback", func(t *testing.T) {
+		for _, cb := range []string{"callback", "/callback", "ftp://example.com/callback", "https://"} {
+			in := SendOpts{StatusCallback: cb}
+			assert.Equal(ErrInvalidStatusCallback, in.Validate(), cb)
+		}
+	})
+}
+
+func TestSendOpts_urlEncodeTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name string
+		in   SendOpts
+		want string
+	}{
+		{"Duration", SendOpts{TTL: 5 * time.Minute}, "5"},
+		{"RoundsUp", SendOpts{TTL: 90 * time.Second}, "2"},
+		{"NeverZero", SendOpts{TTL: time.Second}, "1"},
+		{"TakesPrecedence", SendOpts{TTL: 3 * time.Minute, TTLMinutes: 10}, "3"},
+		{"FallsBack", SendOpts{TTLMinutes: 10}, "10"},
+		{"Unset", SendOpts{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := url.Values{}
+			tt.in.urlEncode(data)
+
+			assert.Equal(tt.want, data.Get("Ttl"))
+		})
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		pages        int
+		pricePerPage float64
+		want         float64
+	}{
+		{0, 0.01, 0},
+		{1, 0.01, 0.01},
+		{10, 0.01, 0.1},
+		{3, 0.0125, 0.0375},
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(tt.want, EstimateCost(tt.pages, tt.pricePerPage), 1e-9)
+	}
+}
+
+func TestSendResponse_EstimateCost(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("OK", func(t *testing.T) {
+		pages := 4
+		in := SendResponse{NumPages: &pages}
+
+		got, err := in.EstimateCost(0.01)
+		assert.NoError(err)
+		assert.InDelta(0.04, got, 1e-9)
+	})
+
+	t.Run("ErrUnknownPageCount", func(t *testing.T) {
+		in := SendResponse{}
+
+		_, err := in.EstimateCost(0.01)
+		assert.Equal(ErrUnknownPageCount, err)
+	})
+}
+
+func TestSendResponse_EqualIgnoring(t *testing.T) {
+	assert := assert.New(t)
+
+	var a, b SendResponse
+	assert.NoError(json.Unmarshal([]byte(sendResponseJSON), &a))
+	assert.NoError(json.Unmarshal([]byte(sendResponseJSON), &b))
+	b.DateUpdated = b.DateUpdated.Add(time.Minute)
+
+	assert.False(a.EqualIgnoring(&b))
+	assert.True(a.EqualIgnoring(&b, "DateUpdated"))
+	assert.True(a.EqualIgnoring(&b, "DateUpdated", "NotAField"))
+
+	b.Status = "delivered"
+	assert.False(a.EqualIgnoring(&b, "DateUpdated"))
+	assert.True(a.EqualIgnoring(&b, "DateUpdated", "Status"))
+
+	assert.False(a.EqualIgnoring(nil))
+	assert.True((*SendResponse)(nil).EqualIgnoring(nil))
+}
+
+func TestSendResponse_MediaStored(t *testing.T) {
+	assert := assert.New(t)
+
+	var stored, notStored SendResponse
+	assert.NoError(json.Unmarshal([]byte(sendResponseJSON), &stored))
+	assert.NoError(json.Unmarshal([]byte(deleteResponseJSON), &notStored))
+
+	assert.True(stored.MediaStored())
+	assert.False(notStored.MediaStored())
+}
+
+func TestSendResponse_NormalizeNumbers(t *testing.T) {
+	assert := assert.New(t)
+
+	in := SendResponse{
+		To:   "+1 (415) 555-1234",
+		From: "sip:kate@example.com?hatchkey=4815162342;transport=TCP",
+	}
+	in.NormalizeNumbers()
+
+	assert.Equal("+14155551234", in.To)
+	assert.Equal("sip:kate@example.com?hatchkey=4815162342;transport=TCP", in.From)
+
+	in = SendResponse{To: "+15558675310", From: " +1.555.867.5309 "}
+	in.NormalizeNumbers()
+
+	assert.Equal("+15558675310", in.To)
+	assert.Equal("+15558675309", in.From)
+
+	in = SendResponse{To: "+15558675310", From: "Acme Fax"}
+	in.NormalizeNumbers()
+
+	assert.Equal("Acme Fax", in.From)
+
+	for _, from := range []string{"Fax 2", "-", ""} {
+		in = SendResponse{From: from}
+		in.NormalizeNumbers()
+
+		assert.Equal(from, in.From)
+	}
+}
+
+func TestSendResponse_TransmissionTime(t *testing.T) {
+	assert := assert.New(t)
+
+	duration := 75
+	assert.Equal(75*time.Second, (&SendResponse{Duration: &duration}).TransmissionTime())
+	assert.Equal(time.Duration(0), (&SendResponse{}).TransmissionTime())
+}
+
+func TestSendResponse_clone(t *testing.T) {
+	assert := assert.New(t)
+
+	price, duration, numPages := "-0.0070", 60, 2
+	in := SendResponse{
+		SID:      faxSID,
+		Price:    &price,
+		Duration: &duration,
+		NumPages: &numPages,
+		Links:    Links{Media: "media", Other: map[string]string{"a": "b"}},
+	}
+
+	got := in.clone()
+	assert.Equal(&in, got)
+	assert.NotSame(in.Price, got.Price)
+	assert.NotSame(in.Duration, got.Duration)
+	assert.NotSame(in.NumPages, got.NumPages)
+
+	got.Links.Other["a"] = "c"
+	assert.Equal("b", in.Links.Other["a"])
+
+	assert.Equal(&SendResponse{SID: faxSID}, (&SendResponse{SID: faxSID}).clone())
+}
+
+func TestMeta_UnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, in := range []string{
+		`{"page": 2, "page_size": 50, "key": "faxes"}`,
+		`{"page": "2", "page_size": "50", "key": "faxes"}`,
+	} {
+		var got Meta
+		assert.NoError(json.Unmarshal([]byte(in), &got), in)
+		assert.Equal(Meta{Page: 2, PageSize: 50, Key: "faxes"}, got, in)
+	}
+
+	t.Run("Null", func(t *testing.T) {
+		var got Meta
+		assert.NoError(json.Unmarshal([]byte(`{"page": null, "page_size": 50}`), &got))
+		assert.Equal(Meta{PageSize: 50}, got)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var got Meta
+		assert.Error(json.Unmarshal([]byte(`{"page": "two"}`), &got))
+	})
+
+	t.Run("StrictDecode", func(t *testing.T) {
+		sc := c.Clone()
+		sc.StrictDecode = true
+
+		var got ListResponse
+		assert.NoError(sc.decode([]byte(`{"faxes": [], "meta": {"page": "2", "page_size": "50"}}`), &got))
+		assert.Equal(2, got.Meta.Page)
+	})
+}
+
+func TestListResponse_IsEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True((&ListResponse{}).IsEmpty())
+	assert.True((&ListResponse{Faxes: []SendResponse{}}).IsEmpty())
+	assert.False((&ListResponse{Faxes: []SendResponse{{SID: faxSID}}}).IsEmpty())
+}
+
+func TestListResponse_CountByStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	in := ListResponse{Faxes: []SendResponse{
+		{Status: "delivered"},
+		{Status: "failed"},
+		{Status: "delivered"},
+		{Status: "queued"},
+	}}
+
+	assert.Equal(map[string]int{"delivered": 2, "failed": 1, "queued": 1}, in.CountByStatus())
+	assert.Empty((&ListResponse{}).CountByStatus())
+}
+
+func TestListResponse_TotalPrice(t *testing.T) {
+	assert := assert.New(t)
+
+	price := func(s string) *string { return &s }
+
+	t.Run("OK", func(t *testing.T) {
+		in := ListResponse{Faxes: []SendResponse{
+			{Price: price("-0.0070"), PriceUnit: "USD"},
+			{Price: nil},
+			{Price: price("-0.0140"), PriceUnit: "USD"},
+		}}
+
+		total, unit, err := in.TotalPrice()
+		assert.NoError(err)
+		assert.InDelta(-0.021, total, 1e-9)
+		assert.Equal("USD", unit)
+	})
+
+	t.Run("Unpriced", func(t *testing.T) {
+		in := ListResponse{Faxes: []SendResponse{{Price: nil}}}
+
+		total, unit, err := in.TotalPrice()
+		assert.NoError(err)
+		assert.Equal(0.0, total)
+		assert.Equal("", unit)
+	})
+
+	t.Run("ErrMixedCurrencies", func(t *testing.T) {
+		in := ListResponse{Faxes: []SendResponse{
+			{Price: price("-0.0070"), PriceUnit: "USD"},
+			{Price: price("-0.0065"), PriceUnit: "EUR"},
+		}}
+
+		_, _, err := in.TotalPrice()
+		assert.Equal(ErrMixedCurrencies, err)
+	})
+
+	t.Run("InvalidPrice", func(t *testing.T) {
+		in := ListResponse{Faxes: []SendResponse{{Price: price("free"), PriceUnit: "USD"}}}
+
+		_, _, err := in.TotalPrice()
+		assert.Error(err)
+	})
+}
+
+func TestStatusCallbackResponse_FaxStatusType(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]statusType{
+		"queued":     StatusQueued,
+		"processing": StatusProcessing,
+		"sending":    StatusSending,
+		"delivered":  StatusDelivered,
+		"receiving":  StatusReceiving,
+		"received":   StatusReceived,
+		"no-answer":  StatusNoAnswer,
+		"busy":       StatusBusy,
+		"failed":     StatusFailed,
+		"canceled":   StatusCanceled,
+	}
+
+	for in, want := range tests {
+		t.Run(in, func(t *testing.T) {
+			cb := StatusCallbackResponse{FaxStatus: in}
+			assert.Equal(want, cb.FaxStatusType())
+		})
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		for _, in := range []string{"", "no_answer", "NoAnswer"} {
+			got := (&StatusCallbackResponse{FaxStatus: in}).FaxStatusType()
+			assert.Equal("", got.String())
+			assert.False(got.IsTerminal())
+		}
+	})
+}
+
+func TestStatusCallbackResponse_ToSendResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Delivered", func(t *testing.T) {
+		cb := StatusCallbackResponse{
+			FaxSid:          "FXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+			AccountSid:      "ACXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+			From:            from,
+			To:              to,
+			RemoteStationID: "+15558675310",
+			FaxStatus:       "delivered",
+			APIVersion:      "v1",
+			NumPages:        3,
+			MediaURL:        "https://www.example.com/fax.pdf",
+		}
+
+		got := cb.ToSendResponse()
+		assert.Equal(cb.FaxSid, got.SID)
+		assert.Equal(cb.AccountSid, got.AccountSid)
+		assert.Equal(from, got.From)
+		assert.Equal(to, got.To)
+		assert.Equal("+15558675310", got.RemoteStationID)
+		assert.Equal("delivered", got.Status)
+		assert.Equal("v1", got.APIVersion)
+		assert.Equal("https://www.example.com/fax.pdf", got.MediaURL)
+
+		if assert.NotNil(got.NumPages) {
+			assert.Equal(3, *got.NumPages)
+		}
+
+		assert.Equal("", got.Direction)
+		assert.Nil(got.Price)
+		assert.Nil(got.Duration)
+	})
+
+	t.Run("NoPages", func(t *testing.T) {
+		got := (&StatusCallbackResponse{FaxStatus: "failed"}).ToSendResponse()
+		assert.Nil(got.NumPages)
+	})
+
+	t.Run("Quality", func(t *testing.T) {
+		got := (&StatusCallbackResponse{FaxStatus: "failed", Quality: "superfine"}).ToSendResponse()
+		assert.Equal("superfine", got.Quality)
+		assert.Equal(QualitySuperfine, got.ParsedQuality)
+	})
+}
+
+func TestStatusCallbackResponse_ToSendOpts(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Quality", func(t *testing.T) {
+		got := (&StatusCallbackResponse{FaxStatus: "failed", Quality: "superfine"}).ToSendOpts()
+
+		want := DefaultSendOpts()
+		want.Quality = QualitySuperfine
+		assert.Equal(want, got)
+	})
+
+	t.Run("NoQuality", func(t *testing.T) {
+		for _, q := range []string{"", "ultrafine"} {
+			got := (&StatusCallbackResponse{FaxStatus: "failed", Quality: q}).ToSendOpts()
+			assert.Equal(DefaultSendOpts(), got, q)
+		}
+	})
+}
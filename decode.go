@@ -0,0 +1,134 @@
+package fox
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// decode unmarshals a success response body into v. If StrictDecode is set on the Client, the body
+// is first checked for fields that have no counterpart in v, which are reported as an error.
+func (c *Client) decode(body []byte, v interface{}) error {
+	if c.StrictDecode {
+		// A json.Decoder's DisallowUnknownFields setting doesn't carry over into types that implement
+		// json.Unmarshaler (such as SendResponse), so the check is made against a method-free shadow
+		// of v's type instead.
+		shadow := reflect.New(shadowType(reflect.TypeOf(v).Elem()))
+
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(shadow.Interface()); err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// decodeFaxes decodes a list response from r token by token, calling fn with each fax in its
+// "faxes" array as it's decoded, so that the faxes are never all held in memory at once. Decoding
+// stops at the first error, including any returned by fn.
+func decodeFaxes(r io.Reader, fn func(SendResponse) error) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if key, _ := tok.(string); key != "faxes" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			continue // "faxes": null
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '[' {
+			return fmt.Errorf("fox: expected [ in list response, got %v", tok)
+		}
+
+		for dec.More() {
+			var sr SendResponse
+			if err := dec.Decode(&sr); err != nil {
+				return err
+			}
+			if err := fn(sr); err != nil {
+				return err
+			}
+		}
+
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// expectDelim reads the next token from dec, returning an error if it isn't the delimiter want.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if got, ok := tok.(json.Delim); !ok || got != want {
+		return fmt.Errorf("fox: expected %v in list response, got %v", want, tok)
+	}
+
+	return nil
+}
+
+// shadowType returns a type with the same JSON shape as t, but built from unnamed types without
+// any methods, so that json.Unmarshaler implementations are bypassed. Leaf values are left as raw
+// JSON, since only unknown fields are of interest here; their types are checked by the decode
+// proper, which may accept more than one encoding of a value (see Meta.UnmarshalJSON).
+func shadowType(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return reflect.PtrTo(shadowType(t.Elem()))
+	case reflect.Slice:
+		return reflect.SliceOf(shadowType(t.Elem()))
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return t
+		}
+		if t == reflect.TypeOf(Links{}) {
+			break // Links keeps unknown links in Other, so they aren't unknown fields
+		}
+
+		fields := make([]reflect.StructField, 0, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue // unexported fields are never decoded
+			}
+
+			fields = append(fields, reflect.StructField{
+				Name:      f.Name,
+				Type:      shadowType(f.Type),
+				Tag:       f.Tag,
+				Anonymous: f.Anonymous,
+			})
+		}
+
+		return reflect.StructOf(fields)
+	}
+
+	return reflect.TypeOf(json.RawMessage(nil))
+}
@@ -0,0 +1,144 @@
+package fox
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_StrictDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func() { c.StrictDecode = false }()
+
+	extraFieldJSON := strings.Replace(getResponseJSON, `"status": "delivered",`, `"status": "delivered", "unexpected": true,`, 1)
+
+	t.Run("Lenient", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(extraFieldJSON))
+		}))
+		defer server.Close()
+
+		c.StrictDecode = false
+
+		got, err := c.Get(faxSID)
+		assert.NoError(err)
+		assert.Equal(QualityFine, got.ParsedQuality)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(extraFieldJSON))
+		}))
+		defer server.Close()
+
+		c.StrictDecode = true
+
+		_, err := c.Get(faxSID)
+		assert.Error(err)
+	})
+
+	t.Run("StrictKnownFields", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, endpoint) {
+				w.Write([]byte(listResponseJSON))
+				return
+			}
+
+			w.Write([]byte(getResponseJSON))
+		}))
+		defer server.Close()
+
+		c.StrictDecode = true
+
+		got, err := c.Get(faxSID)
+		assert.NoError(err)
+		assert.Equal(QualityFine, got.ParsedQuality)
+
+		_, err = c.List()
+		assert.NoError(err)
+	})
+
+	t.Run("StrictExtraLinks", func(t *testing.T) {
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(strings.Replace(getResponseJSON, `"links": {`, `"links": {"media_instance": "https://fax.twilio.com/v1/Faxes/FX1/Media/ME1", `, 1)))
+		}))
+		defer server.Close()
+
+		c.StrictDecode = true
+
+		got, err := c.Get(faxSID)
+		assert.NoError(err)
+		assert.Contains(got.Links.Other, "media_instance")
+	})
+}
+
+func TestClient_ListStream(t *testing.T) {
+	assert := assert.New(t)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(resendListResponseJSON))
+	}))
+	defer server.Close()
+
+	t.Run("OK", func(t *testing.T) {
+		var sids []string
+
+		err := c.ListStream(nil, func(sr SendResponse) error {
+			sids = append(sids, sr.SID)
+			return nil
+		})
+		assert.NoError(err)
+		assert.Equal([]string{
+			"FX0000000000000000000000000000000A",
+			"FX0000000000000000000000000000000B",
+			"FX0000000000000000000000000000000C",
+			"FX0000000000000000000000000000000D",
+		}, sids)
+	})
+
+	t.Run("EarlyReturn", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+
+		err := c.ListStream(nil, func(sr SendResponse) error {
+			calls++
+			if calls == 2 {
+				return stop
+			}
+			return nil
+		})
+		assert.Equal(stop, err)
+		assert.Equal(2, calls)
+	})
+}
+
+func TestDecodeFaxes(t *testing.T) {
+	assert := assert.New(t)
+
+	count := func(s string) (int, error) {
+		n := 0
+		err := decodeFaxes(strings.NewReader(s), func(SendResponse) error {
+			n++
+			return nil
+		})
+		return n, err
+	}
+
+	n, err := count(listResponseJSON)
+	assert.NoError(err)
+	assert.Equal(1, n)
+
+	n, err = count(`{"meta": {"page": 0}, "faxes": null}`)
+	assert.NoError(err)
+	assert.Equal(0, n)
+
+	_, err = count(`{"faxes": {}}`)
+	assert.Error(err)
+
+	_, err = count(`[]`)
+	assert.Error(err)
+}
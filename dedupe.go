@@ -0,0 +1,91 @@
+package fox
+
+import (
+	"context"
+	"crypto/sha256"
+	"time"
+)
+
+// dedupeEntry describes a send tracked for DedupeWindow. done is closed once the send completes,
+// after which sr is the response, or nil if the send failed.
+type dedupeEntry struct {
+	done    chan struct{}
+	sr      *SendResponse
+	expires time.Time
+}
+
+// completed reports whether the send tracked by e has completed.
+func (e *dedupeEntry) completed() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// sendOnce implements Send when DedupeWindow is set. A send identical to one still in flight waits
+// for it, and one identical to a send that succeeded within the window returns a deep copy of its
+// response; otherwise, the fax is sent. A failed send isn't remembered, so it can be retried.
+func (c *Client) sendOnce(ctx context.Context, to, from, mediaURL string, opts *SendOpts) (*SendResponse, error) {
+	key := dedupeKey(to, from, mediaURL)
+
+	var e *dedupeEntry
+	for {
+		c.mu.Lock()
+		prev, ok := c.sends[key]
+		if !ok || (prev.completed() && c.clock().Now().After(prev.expires)) {
+			c.pruneSends()
+			if c.sends == nil {
+				c.sends = map[[sha256.Size]byte]*dedupeEntry{}
+			}
+
+			e = &dedupeEntry{done: make(chan struct{})}
+			c.sends[key] = e
+			c.mu.Unlock()
+			break
+		}
+		c.mu.Unlock()
+
+		<-prev.done
+		if prev.sr != nil && c.clock().Now().Before(prev.expires) {
+			return prev.sr.clone(), nil
+		}
+	}
+
+	sr, err := c.send(ctx, to, from, mediaURL, opts)
+
+	c.mu.Lock()
+	if err != nil {
+		delete(c.sends, key)
+	} else {
+		e.sr = sr.clone()
+		e.expires = c.clock().Now().Add(c.DedupeWindow)
+	}
+	c.mu.Unlock()
+	close(e.done)
+
+	return sr, err
+}
+
+// pruneSends removes the completed sends whose window has passed, which would otherwise be kept
+// until an identical send replaced them. It does so at most once per DedupeWindow, to keep sends
+// cheap. c.mu must be held.
+func (c *Client) pruneSends() {
+	now := c.clock().Now()
+	if now.Sub(c.sendsPruned) < c.DedupeWindow {
+		return
+	}
+
+	for k, e := range c.sends {
+		if e.completed() && now.After(e.expires) {
+			delete(c.sends, k)
+		}
+	}
+	c.sendsPruned = now
+}
+
+// dedupeKey returns the key identifying sends with the given to and from numbers and media URL.
+func dedupeKey(to, from, mediaURL string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(to + "\x00" + from + "\x00" + mediaURL))
+}
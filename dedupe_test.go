@@ -0,0 +1,138 @@
+package fox
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_DedupeWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	var mu sync.Mutex
+	sends := 0
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sends++
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(sendResponseJSON))
+	}))
+	defer server.Close()
+
+	t.Run("Disabled", func(t *testing.T) {
+		sends = 0
+
+		c.Send(to, from, faxMediaURL)
+		c.Send(to, from, faxMediaURL)
+		assert.Equal(2, sends)
+	})
+
+	t.Run("WithinWindow", func(t *testing.T) {
+		sends = 0
+
+		dc := c.Clone()
+		dc.DedupeWindow = time.Minute
+
+		first, err := dc.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+
+		second, err := dc.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+		assert.Equal(first, second)
+		assert.NotSame(first, second)
+		assert.Equal(1, sends)
+
+		_, err = dc.Send(to, from, "https://www.example.com/other.pdf")
+		assert.NoError(err)
+		assert.Equal(2, sends)
+	})
+
+	t.Run("InFlight", func(t *testing.T) {
+		sends = 0
+
+		dc := c.Clone()
+		dc.DedupeWindow = time.Minute
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				dc.Send(to, from, faxMediaURL)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(1, sends)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		sends = 0
+
+		dc := c.Clone()
+		dc.DedupeWindow = time.Millisecond
+
+		dc.Send(to, from, faxMediaURL)
+		time.Sleep(5 * time.Millisecond)
+		dc.Send(to, from, faxMediaURL)
+		assert.Equal(2, sends)
+	})
+
+	t.Run("Pruned", func(t *testing.T) {
+		fc := &fakeClock{now: time.Now()}
+
+		dc := c.Clone()
+		dc.DedupeWindow = time.Minute
+		dc.Clock = fc
+
+		dc.Send(to, from, "https://www.example.com/a.pdf")
+		dc.Send(to, from, "https://www.example.com/b.pdf")
+
+		fc.Advance(2 * time.Minute)
+		dc.Send(to, from, "https://www.example.com/c.pdf")
+
+		dc.mu.Lock()
+		defer dc.mu.Unlock()
+
+		assert.Len(dc.sends, 1)
+		assert.Contains(dc.sends, dedupeKey(to, from, "https://www.example.com/c.pdf"))
+	})
+
+	t.Run("DeepCopy", func(t *testing.T) {
+		sends = 0
+
+		dc := c.Clone()
+		dc.DedupeWindow = time.Minute
+
+		price := "-0.0070"
+		done := make(chan struct{})
+		close(done)
+
+		dc.sends = map[[sha256.Size]byte]*dedupeEntry{
+			dedupeKey(to, from, faxMediaURL): {
+				done:    done,
+				sr:      &SendResponse{Price: &price, Links: Links{Other: map[string]string{"a": "b"}}},
+				expires: time.Now().Add(time.Minute),
+			},
+		}
+
+		first, err := dc.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+
+		*first.Price = "1.00"
+		first.Links.Other["a"] = "c"
+
+		second, err := dc.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+		assert.Equal("-0.0070", *second.Price)
+		assert.Equal("b", second.Links.Other["a"])
+		assert.Equal(0, sends)
+	})
+}
@@ -0,0 +1,59 @@
+package fox
+
+import "sync"
+
+var (
+	defaultMu     sync.RWMutex
+	defaultClient *Client // used by the package-level Get, List and Send; see SetDefaultClient
+)
+
+// SetDefaultClient sets the Client used by the package-level Get, List and Send functions, which
+// are convenient for short programs that need only one Client. Passing nil unsets it.
+func SetDefaultClient(c *Client) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	defaultClient = c
+}
+
+// getDefaultClient returns the Client set with SetDefaultClient, or ErrNoDefaultClient if none is.
+func getDefaultClient() (*Client, error) {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+
+	if defaultClient == nil {
+		return nil, ErrNoDefaultClient
+	}
+
+	return defaultClient, nil
+}
+
+// Get calls Get on the default Client set with SetDefaultClient.
+func Get(sid string) (*SendResponse, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Get(sid)
+}
+
+// List calls List on the default Client set with SetDefaultClient.
+func List(opts ...*ListOpts) (*ListResponse, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.List(opts...)
+}
+
+// Send calls Send on the default Client set with SetDefaultClient.
+func Send(to, from, mediaURL string, sendOpts ...*SendOpts) (*SendResponse, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Send(to, from, mediaURL, sendOpts...)
+}
@@ -0,0 +1,53 @@
+package fox
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultClient(t *testing.T) {
+	assert := assert.New(t)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(sendResponseJSON))
+		case r.URL.Path == "/"+version+"/"+endpoint:
+			w.Write([]byte(listResponseJSON))
+		default:
+			w.Write([]byte(getResponseJSON))
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Set", func(t *testing.T) {
+		SetDefaultClient(c)
+		defer SetDefaultClient(nil)
+
+		got, err := Get(faxSID)
+		assert.NoError(err)
+		assert.Equal(faxSID, got.SID)
+
+		lr, err := List()
+		assert.NoError(err)
+		assert.NotEmpty(lr.Faxes)
+
+		sr, err := Send(to, from, faxMediaURL)
+		assert.NoError(err)
+		assert.Equal("queued", sr.Status)
+	})
+
+	t.Run("ErrNoDefaultClient", func(t *testing.T) {
+		_, err := Get(faxSID)
+		assert.Equal(ErrNoDefaultClient, err)
+
+		_, err = List()
+		assert.Equal(ErrNoDefaultClient, err)
+
+		_, err = Send(to, from, faxMediaURL)
+		assert.Equal(ErrNoDefaultClient, err)
+	})
+}
@@ -5,14 +5,73 @@ import "errors"
 var (
 	// ErrNotAuthenticated indicates that the account SID and/or auth token are unspecified.
 	ErrNotAuthenticated = errors.New("fox: account SID and/or auth token not specified")
+	// ErrInvalidCredentials indicates that the account SID, auth token or API key are malformed.
+	ErrInvalidCredentials = errors.New("fox: credentials are malformed")
+	// ErrNoDefaultClient indicates that a package-level function was called before SetDefaultClient.
+	ErrNoDefaultClient = errors.New("fox: no default client set")
 	// ErrInvalidFaxNumber indicates that the fax number provided is invalid.
 	ErrInvalidFaxNumber = errors.New("fox: fax number supplied is invalid")
 	// ErrMissingSID indicates that a SID is required but was not supplied.
 	ErrMissingSID = errors.New("fox: SID is required")
+	// ErrInvalidSID indicates that a SID doesn't have the expected prefix and length.
+	ErrInvalidSID = errors.New("fox: SID is invalid")
 	// ErrMissingToNumber indicates that a to number is required but was not supplied.
 	ErrMissingToNumber = errors.New("fox: to number is required")
 	// ErrMissingFromNumber indicates that a from number is required but was not supplied.
 	ErrMissingFromNumber = errors.New("fox: from number is required")
 	// ErrMissingMediaURL indicates that a media URL is required but was not supplied.
 	ErrMissingMediaURL = errors.New("fox: media URL is required")
+	// ErrInvalidMediaURL indicates that a media URL is not an absolute HTTP or HTTPS URL.
+	ErrInvalidMediaURL = errors.New("fox: media URL must be an absolute HTTP or HTTPS URL")
+	// ErrPrivateMediaURL indicates that a media URL's host isn't reachable from the public internet.
+	ErrPrivateMediaURL = errors.New("fox: media URL must be publicly accessible")
+	// ErrMediaUnreachable indicates that a HEAD request for a media URL failed.
+	ErrMediaUnreachable = errors.New("fox: media URL is unreachable")
+	// ErrUnsupportedMediaType indicates that a media URL serves content of a type Twilio can't fax.
+	ErrUnsupportedMediaType = errors.New("fox: media type is not supported")
+	// ErrInvalidQuality indicates that a quality string is not one of "standard", "fine" or
+	// "superfine".
+	ErrInvalidQuality = errors.New("fox: quality is invalid")
+	// ErrInvalidDirection indicates that a direction string is not one of "inbound" or "outbound".
+	ErrInvalidDirection = errors.New("fox: direction is invalid")
+	// ErrMissingQuality indicates that at least one quality is required but none were supplied.
+	ErrMissingQuality = errors.New("fox: at least one quality is required")
+	// ErrIncompleteSIPAuth indicates that only one of a SIP auth username and password was supplied.
+	ErrIncompleteSIPAuth = errors.New("fox: SIP auth username and password must be supplied together")
+	// ErrInvalidTTL indicates that a negative TTL was supplied.
+	ErrInvalidTTL = errors.New("fox: TTL must not be negative")
+	// ErrInvalidStatusCallback indicates that a status callback is not an absolute HTTP(S) URL.
+	ErrInvalidStatusCallback = errors.New("fox: status callback must be an absolute HTTP or HTTPS URL")
+	// ErrTooManyRedirects indicates that a media download was redirected too many times.
+	ErrTooManyRedirects = errors.New("fox: too many redirects")
+	// ErrUnknownPageCount indicates that a fax's page count isn't known yet.
+	ErrUnknownPageCount = errors.New("fox: page count is unknown")
+	// ErrInvalidBackoff indicates that a polling interval was non-positive, or that the maximum
+	// interval was less than the minimum.
+	ErrInvalidBackoff = errors.New("fox: invalid backoff interval")
+	// ErrNotModified indicates that a resource hasn't changed since the time given in a conditional
+	// request.
+	ErrNotModified = errors.New("fox: not modified")
+	// ErrNoNextPage indicates that there's no page of results following the current one.
+	ErrNoNextPage = errors.New("fox: no next page")
+	// ErrCallbackChannelClosed indicates that a channel of status callbacks was closed before the
+	// callback being waited for arrived.
+	ErrCallbackChannelClosed = errors.New("fox: callback channel closed")
+	// ErrMixedCurrencies indicates that prices in more than one currency unit can't be totaled.
+	ErrMixedCurrencies = errors.New("fox: prices are in mixed currencies")
+	// ErrUnsupportedTransport indicates that the Client's HTTP transport isn't an *http.Transport, so
+	// its settings can't be changed.
+	ErrUnsupportedTransport = errors.New("fox: HTTP transport is not an *http.Transport")
+	// ErrBadRequest matches, with errors.Is, an ErrorResponse or HTTPError with the status
+	// 400 BAD REQUEST.
+	ErrBadRequest = errors.New("fox: bad request")
+	// ErrUnauthorized matches, with errors.Is, an ErrorResponse or HTTPError with the status
+	// 401 UNAUTHORIZED.
+	ErrUnauthorized = errors.New("fox: unauthorized")
+	// ErrNotFound matches, with errors.Is, an ErrorResponse or HTTPError with the status
+	// 404 NOT FOUND.
+	ErrNotFound = errors.New("fox: not found")
+	// ErrMediaTooLarge indicates that Twilio rejected the fax media for exceeding the maximum size. It
+	// isn't returned directly; use errors.Is to check whether an ErrorResponse matches it.
+	ErrMediaTooLarge = errors.New("fox: media is too large")
 )
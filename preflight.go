@@ -0,0 +1,101 @@
+package fox
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// preflightMediaURL checks that mediaURL is an absolute HTTP(S) URL whose host isn't obviously
+// private, such as localhost or a loopback or private network address, since Twilio must be able
+// to fetch the media from the public internet. If the Client's CheckMediaReachable is set, it also
+// issues a HEAD request to confirm the media can be fetched.
+func (c *Client) preflightMediaURL(ctx context.Context, mediaURL string) error {
+	u, err := url.Parse(mediaURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidMediaURL
+	}
+	if isPrivateHost(u.Hostname()) {
+		return ErrPrivateMediaURL
+	}
+
+	if !c.CheckMediaReachable {
+		return nil
+	}
+
+	_, err = c.headMedia(ctx, mediaURL)
+	return err
+}
+
+// faxMediaTypes holds the media types Twilio can fax.
+var faxMediaTypes = map[string]bool{
+	"application/pdf": true,
+	"image/tiff":      true,
+}
+
+// VerifyMediaURL issues a HEAD request for mediaURL and checks that it serves a media type Twilio can
+// fax, PDF or TIFF, rather than, say, an HTML error page. Unlike the checks enabled by
+// CheckMediaURL, it's never called by Send. It returns an error wrapping ErrMediaUnreachable if the
+// request fails, or ErrUnsupportedMediaType if the Content-Type isn't supported.
+func (c *Client) VerifyMediaURL(ctx context.Context, mediaURL string) error {
+	u, err := url.Parse(mediaURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidMediaURL
+	}
+
+	header, err := c.headMedia(ctx, mediaURL)
+	if err != nil {
+		return err
+	}
+
+	contentType := header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !faxMediaTypes[mediaType] {
+		return fmt.Errorf("%w: %q is served as %q, not PDF or TIFF", ErrUnsupportedMediaType,
+			mediaURL, contentType)
+	}
+
+	return nil
+}
+
+// headMedia issues a HEAD request for mediaURL, returning the response's header. Failed requests
+// and error statuses are reported as errors wrapping ErrMediaUnreachable.
+func (c *Client) headMedia(ctx context.Context, mediaURL string) (http.Header, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodHead, mediaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.HTTPClient.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMediaUnreachable, err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("%w: HEAD returned %s", ErrMediaUnreachable, res.Status)
+	}
+
+	return res.Header, nil
+}
+
+// isPrivateHost reports whether host, a host name or IP address, is obviously not reachable from
+// the public internet. Host names other than localhost aren't resolved.
+func isPrivateHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast()
+}
@@ -0,0 +1,112 @@
+package fox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CheckMediaURL(t *testing.T) {
+	assert := assert.New(t)
+
+	var heads int
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.Host == "missing.example.com":
+			heads++
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodHead:
+			heads++
+		default:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(sendResponseJSON))
+		}
+	}))
+	defer server.Close()
+
+	c.CheckMediaURL = true
+	defer func() { c.CheckMediaURL = false }()
+
+	t.Run("Localhost", func(t *testing.T) {
+		for _, u := range []string{"http://localhost/fax.pdf", "https://127.0.0.1/fax.pdf", "https://10.0.0.8/fax.pdf", "http://[::1]:8080/fax.pdf"} {
+			_, err := c.Send(to, from, u)
+			assert.Equal(ErrPrivateMediaURL, err, u)
+		}
+	})
+
+	t.Run("InvalidScheme", func(t *testing.T) {
+		for _, u := range []string{"ftp://www.example.com/fax.pdf", "/fax.pdf", "https://"} {
+			_, err := c.Send(to, from, u)
+			assert.Equal(ErrInvalidMediaURL, err, u)
+		}
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		_, err := c.Send(to, from, "http://www.example.com/fax.pdf")
+		assert.NoError(err)
+	})
+
+	t.Run("HTTPS", func(t *testing.T) {
+		_, err := c.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+		assert.Equal(0, heads)
+	})
+
+	t.Run("Reachable", func(t *testing.T) {
+		c.CheckMediaReachable = true
+		defer func() { c.CheckMediaReachable = false }()
+
+		_, err := c.Send(to, from, "http://www.example.com/fax.pdf")
+		assert.NoError(err)
+		assert.Equal(1, heads)
+
+		_, err = c.Send(to, from, "http://missing.example.com/fax.pdf")
+		assert.True(errors.Is(err, ErrMediaUnreachable))
+		assert.Equal(2, heads)
+	})
+}
+
+func TestClient_VerifyMediaURL(t *testing.T) {
+	assert := assert.New(t)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodHead, r.Method)
+
+		switch r.URL.Path {
+		case "/fax.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+		case "/fax.tiff":
+			w.Header().Set("Content-Type", "image/tiff")
+		case "/missing.pdf":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Supported", func(t *testing.T) {
+		assert.NoError(c.VerifyMediaURL(context.Background(), "http://www.example.com/fax.pdf"))
+		assert.NoError(c.VerifyMediaURL(context.Background(), "http://www.example.com/fax.tiff"))
+	})
+
+	t.Run("ErrUnsupportedMediaType", func(t *testing.T) {
+		err := c.VerifyMediaURL(context.Background(), "http://www.example.com/error")
+		assert.True(errors.Is(err, ErrUnsupportedMediaType))
+		assert.Contains(err.Error(), "text/html")
+	})
+
+	t.Run("ErrMediaUnreachable", func(t *testing.T) {
+		err := c.VerifyMediaURL(context.Background(), "http://www.example.com/missing.pdf")
+		assert.True(errors.Is(err, ErrMediaUnreachable))
+	})
+
+	t.Run("ErrInvalidMediaURL", func(t *testing.T) {
+		assert.Equal(ErrInvalidMediaURL, c.VerifyMediaURL(context.Background(), "/fax.pdf"))
+	})
+}
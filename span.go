@@ -0,0 +1,81 @@
+package fox
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Span describes a traced operation started by a SpanStarter. It mirrors the subset of an
+// OpenTelemetry span that the Client uses, so that a thin adapter can satisfy it without this
+// package depending on OpenTelemetry.
+type Span interface {
+	// SetAttribute sets an attribute of the span, such as "http.status_code".
+	SetAttribute(key string, value interface{})
+	// RecordError records an error that occurred during the operation.
+	RecordError(err error)
+	// End completes the span.
+	End()
+}
+
+// SpanStarter starts a Span for the named operation, such as "fox.Send", returning it along with a
+// context carrying it.
+type SpanStarter func(ctx context.Context, name string) (context.Context, Span)
+
+// spanName returns the name of the operation r performs, derived from its method and whether its
+// path addresses a single fax: "fox.Get", "fox.List", "fox.Send", "fox.Cancel" or "fox.Delete".
+// Other requests, such as media downloads, are named for their method alone, e.g. "fox.GET".
+func spanName(r *http.Request) string {
+	hasSID := pathSID(r.URL.Path) != ""
+
+	switch {
+	case r.Method == http.MethodGet && hasSID:
+		return "fox.Get"
+	case r.Method == http.MethodGet && isFaxesPath(r.URL.Path):
+		return "fox.List"
+	case r.Method == http.MethodPost && hasSID:
+		return "fox.Cancel"
+	case r.Method == http.MethodPost && isFaxesPath(r.URL.Path):
+		return "fox.Send"
+	case r.Method == http.MethodDelete && hasSID:
+		return "fox.Delete"
+	}
+
+	return "fox." + r.Method
+}
+
+// isFaxesPath reports whether p is the path of the faxes collection, e.g. "/v1/Faxes".
+func isFaxesPath(p string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(p, "/"), "/"+endpoint)
+}
+
+// pathSID returns the fax SID addressed by p, e.g. "FX123" for "/v1/Faxes/FX123", or an empty
+// string if p doesn't address a single fax.
+func pathSID(p string) string {
+	i := strings.Index(p, "/"+endpoint+"/")
+	if i < 0 {
+		return ""
+	}
+
+	sid := p[i+len(endpoint)+2:]
+	if sid == "" || strings.Contains(sid, "/") {
+		return ""
+	}
+
+	return sid
+}
+
+// statusCode returns the HTTP status code of a request's outcome, taken from res or, for an error
+// response, from err. It returns 0 if the request didn't receive a response.
+func statusCode(res *http.Response, err error) int {
+	switch err := err.(type) {
+	case nil:
+		return res.StatusCode
+	case *ErrorResponse:
+		return err.Status
+	case *HTTPError:
+		return err.StatusCode
+	}
+
+	return 0
+}
@@ -0,0 +1,97 @@
+package fox
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSpan struct {
+	name  string
+	attrs map[string]interface{}
+	errs  []error
+	ended bool
+}
+
+func (s *fakeSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *fakeSpan) RecordError(err error)                      { s.errs = append(s.errs, err) }
+func (s *fakeSpan) End()                                       { s.ended = true }
+
+func TestClient_StartSpan(t *testing.T) {
+	assert := assert.New(t)
+
+	var spans []*fakeSpan
+
+	c.StartSpan = func(ctx context.Context, name string) (context.Context, Span) {
+		s := &fakeSpan{name: name, attrs: map[string]interface{}{}}
+		spans = append(spans, s)
+		return ctx, s
+	}
+	defer func() { c.StartSpan = nil }()
+
+	t.Run("Send", func(t *testing.T) {
+		spans = nil
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(sendResponseJSON))
+		}))
+		defer server.Close()
+
+		_, err := c.Send(to, from, faxMediaURL)
+		assert.NoError(err)
+
+		if assert.Len(spans, 1) {
+			assert.Equal("fox.Send", spans[0].name)
+			assert.Equal(http.MethodPost, spans[0].attrs["http.method"])
+			assert.Equal(http.StatusCreated, spans[0].attrs["http.status_code"])
+			assert.Empty(spans[0].errs)
+			assert.True(spans[0].ended)
+		}
+	})
+
+	t.Run("ErrorResponse", func(t *testing.T) {
+		spans = nil
+
+		server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorResponseJSON))
+		}))
+		defer server.Close()
+
+		_, err := c.Get(faxSID)
+		assert.Error(err)
+
+		if assert.Len(spans, 1) {
+			assert.Equal("fox.Get", spans[0].name)
+			assert.Equal(faxSID, spans[0].attrs["fax.sid"])
+			assert.Equal(http.StatusNotFound, spans[0].attrs["http.status_code"])
+			assert.Equal([]error{err}, spans[0].errs)
+			assert.True(spans[0].ended)
+		}
+	})
+}
+
+func TestSpanName(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{http.MethodGet, "/v1/Faxes/FX123", "fox.Get"},
+		{http.MethodGet, "/v1/Faxes", "fox.List"},
+		{http.MethodPost, "/v1/Faxes", "fox.Send"},
+		{http.MethodPost, "/v1/Faxes/FX123", "fox.Cancel"},
+		{http.MethodDelete, "/v1/Faxes/FX123", "fox.Delete"},
+		{http.MethodGet, "/v1/Faxes/FX123/Media", "fox.GET"},
+	}
+
+	for _, tt := range tests {
+		r, _ := http.NewRequest(tt.method, "https://fax.twilio.com"+tt.path, nil)
+		assert.Equal(tt.want, spanName(r), tt.path)
+	}
+}
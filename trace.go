@@ -0,0 +1,89 @@
+package fox
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// TraceInfo describes the timings captured for a single request when a Client's TraceHook is set.
+// Any phase that did not occur (for example, the DNS lookup and connect phases on a reused
+// connection, or the TLS handshake for a plain HTTP request) has a zero duration.
+type TraceInfo struct {
+	// DNSLookup is the time taken to resolve the host name.
+	DNSLookup time.Duration
+	// Connect is the time taken to establish the TCP connection.
+	Connect time.Duration
+	// TLSHandshake is the time taken to complete the TLS handshake.
+	TLSHandshake time.Duration
+	// TimeToFirstByte is the time from the start of the request until the first byte of the
+	// response headers was received.
+	TimeToFirstByte time.Duration
+}
+
+// tracer accumulates request timings from the callbacks of an httptrace.ClientTrace. The callbacks
+// may be invoked from separate goroutines, so access to the timings is guarded by a mutex.
+type tracer struct {
+	mu           sync.Mutex
+	info         TraceInfo
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+}
+
+// trace returns a shallow copy of r whose context carries an httptrace.ClientTrace reporting to t.
+func (t *tracer) trace(r *http.Request) *http.Request {
+	t.start = time.Now()
+
+	ct := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			t.dnsStart = time.Now()
+			t.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			t.info.DNSLookup = time.Since(t.dnsStart)
+			t.mu.Unlock()
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			t.connectStart = time.Now()
+			t.mu.Unlock()
+		},
+		ConnectDone: func(string, string, error) {
+			t.mu.Lock()
+			t.info.Connect = time.Since(t.connectStart)
+			t.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			t.tlsStart = time.Now()
+			t.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			t.info.TLSHandshake = time.Since(t.tlsStart)
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			t.info.TimeToFirstByte = time.Since(t.start)
+			t.mu.Unlock()
+		},
+	}
+
+	return r.WithContext(httptrace.WithClientTrace(r.Context(), ct))
+}
+
+// summary returns a copy of the timings captured so far.
+func (t *tracer) summary() *TraceInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	info := t.info
+	return &info
+}
@@ -0,0 +1,36 @@
+package fox
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_TraceHook(t *testing.T) {
+	assert := assert.New(t)
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(getResponseJSON))
+	}))
+	defer server.Close()
+
+	var got *TraceInfo
+	c.TraceHook = func(info *TraceInfo) {
+		got = info
+	}
+	defer func() { c.TraceHook = nil }()
+
+	_, err := c.Get(faxSID)
+	assert.NoError(err)
+
+	if got == nil {
+		t.Error("trace hook did not fire")
+		t.FailNow()
+	}
+
+	assert.True(got.DNSLookup >= 0)
+	assert.True(got.Connect >= 0)
+	assert.True(got.TLSHandshake >= 0)
+	assert.True(got.TimeToFirstByte > 0)
+}
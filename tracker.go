@@ -0,0 +1,36 @@
+package fox
+
+import "context"
+
+// FaxTracker tracks a fax sent with SendTracked through to completion.
+type FaxTracker struct {
+	// SID is the SID of the fax being tracked.
+	SID string
+
+	c *Client
+}
+
+// SendTracked sends a fax like Send, returning a FaxTracker for it as soon as Twilio has accepted
+// it.
+func (c *Client) SendTracked(to, from, mediaURL string, opts ...*SendOpts) (*FaxTracker, error) {
+	sr, err := c.Send(to, from, mediaURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FaxTracker{SID: sr.SID, c: c}, nil
+}
+
+// Poll retrieves the fax's current state. Unlike Client.Get, it always reaches Twilio, bypassing
+// the cache.
+func (ft *FaxTracker) Poll(ctx context.Context) (*SendResponse, error) {
+	ft.c.uncache(ft.SID)
+	return ft.c.get(ctx, ft.SID)
+}
+
+// Wait polls the fax, with an interval that grows from 5 seconds to a minute, until its status is
+// terminal, returning the final response. It stops with ctx's error if ctx is done first. See
+// Client.WaitForTerminalBackoff to choose the interval.
+func (ft *FaxTracker) Wait(ctx context.Context) (*SendResponse, error) {
+	return ft.c.WaitForTerminalBackoff(ctx, ft.SID, pollMin, pollMax)
+}
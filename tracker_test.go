@@ -0,0 +1,59 @@
+package fox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SendTracked(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(min, max time.Duration) {
+		pollMin, pollMax = min, max
+	}(pollMin, pollMax)
+	pollMin, pollMax = time.Millisecond, time.Millisecond
+
+	statuses := []string{"queued", "processing", "sending", "delivered"}
+	polls := 0
+
+	server := makeServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(sendResponseJSON))
+			return
+		}
+
+		status := statuses[len(statuses)-1]
+		if polls < len(statuses) {
+			status = statuses[polls]
+		}
+		polls++
+
+		fmt.Fprintf(w, `{"sid": "%s", "status": "%s"}`, faxSID, status)
+	}))
+	defer server.Close()
+
+	ft, err := c.SendTracked(to, from, faxMediaURL)
+	assert.NoError(err)
+	assert.Equal(faxSID, ft.SID)
+
+	got, err := ft.Poll(context.Background())
+	assert.NoError(err)
+	assert.Equal("queued", got.Status)
+
+	got, err = ft.Wait(context.Background())
+	assert.NoError(err)
+	assert.Equal("delivered", got.Status)
+	assert.Equal(len(statuses), polls)
+
+	t.Run("SendError", func(t *testing.T) {
+		ft, err := c.SendTracked("", from, faxMediaURL)
+		assert.Equal(ErrMissingToNumber, err)
+		assert.Nil(ft)
+	})
+}